@@ -28,15 +28,18 @@ type K8sCache interface {
 	UpdateK8sNode(name string, podCIDR string, providerID string,
 		Addresses []*node.NodeAddress, nodeInfo *node.NodeSystemInfo) error
 	DeleteK8sNode(nodeName string) error
+	DeleteK8sNodeAndPods(nodeName string) ([]string, error)
 
 	RetrieveAllK8sNodes() []*node.Node
 
 	CreatePod(name string, namespace string, label []*pod2.Pod_Label, IPAddress,
 		hostIPAdd string, container []*pod2.Pod_Container) error
-	RetrievePod(name string) (*telemetrymodel.Pod, error)
+	RetrievePod(name string, namespace string) (*telemetrymodel.Pod, error)
+	RetrievePodsByHostIPAddr(hostIPAddress string) []*telemetrymodel.Pod
+	RetrievePodByIPAddr(IPAddress string) (*telemetrymodel.Pod, error)
 	UpdatePod(name string, namespace string, label []*telemetrymodel.PodLabel,
 		IPAddress, hostIPAddress string, container []*pod2.Pod_Container) error
-	DeletePod(name string) error
+	DeletePod(name string, namespace string) error
 
 	RetrieveAllPods() []*telemetrymodel.Pod
 
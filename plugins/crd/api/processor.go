@@ -14,6 +14,14 @@
 
 package api
 
+// ValidationResult is the result of a single validation: the name of the
+// validation and the number of errors it found. Details about the errors
+// are recorded in the validation report.
+type ValidationResult struct {
+	Name       string
+	ErrorCount int
+}
+
 // ContivTelemetryProcessor defines the methods for the telemetry processor.
 type ContivTelemetryProcessor interface {
 	Validate()
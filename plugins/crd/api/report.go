@@ -25,14 +25,93 @@ const (
 	GlobalMsg = "global"
 )
 
+// Severity is the severity of a report entry.
+type Severity int
+
+const (
+	// SeverityInfo marks informational entries, such as summaries of
+	// successful validations
+	SeverityInfo Severity = iota
+	// SeverityWarning marks entries describing suspicious, but not
+	// necessarily erroneous state
+	SeverityWarning
+	// SeverityError marks entries describing errors
+	SeverityError
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Categories of report entries. Validators tag the entries they record
+// with the category of the checked data, so that entries can be counted
+// by category without parsing the report text.
+const (
+	// CategoryInterfaces marks entries about interface configuration and state
+	CategoryInterfaces = "interfaces"
+	// CategoryVxlan marks entries about VXLAN tunnels
+	CategoryVxlan = "vxlan"
+	// CategoryBridgeDomain marks entries about bridge domains
+	CategoryBridgeDomain = "bridge-domain"
+	// CategoryL2Fib marks entries about L2 FIB tables
+	CategoryL2Fib = "l2fib"
+	// CategoryArp marks entries about ARP tables
+	CategoryArp = "arp"
+	// CategoryPods marks entries about pods
+	CategoryPods = "pods"
+	// CategoryNodes marks entries about nodes and their addressing
+	CategoryNodes = "nodes"
+	// CategoryRoutes marks entries about routes
+	CategoryRoutes = "routes"
+	// CategoryTelemetry marks entries about VPP telemetry counters
+	CategoryTelemetry = "telemetry"
+	// CategorySummary marks the summaries of validations; they are not
+	// counted by category or by severity, so that each finding is counted
+	// once
+	CategorySummary = "summary"
+)
+
+// ReportEntry is a single report line together with the name of the node
+// (or report bin) it was recorded for.
+type ReportEntry struct {
+	NodeName string
+	Message  string
+}
+
+// ReportSnapshot is a copy of a complete report taken at the end of a
+// validation run.
+type ReportSnapshot struct {
+	TimeStamp time.Time
+	Data      telemetrymodel.Reports
+}
+
 // Report is the interface for collecting validation status/error messages
 // and for printing them out.
 type Report interface {
 	LogErrAndAppendToNodeReport(nodeName string, errString string)
 	AppendToNodeReport(nodeName string, errString string)
+	AppendToNodeReportWithSeverity(nodeName string, severity Severity, msg string)
+	LogErrAndAppendToNodeReportWithCategory(nodeName string, category string, errString string)
+	AppendToNodeReportWithCategory(nodeName string, category string, errString string)
+	Append(nodeName string, severity Severity, category string, msg string)
 	SetTimeStamp(time time.Time)
 	GetTimeStamp() time.Time
 	Clear()
 	Print()
 	RetrieveReport() telemetrymodel.Reports
+	FilterReport(substr string) []ReportEntry
+	GlobalMessages() []ReportEntry
+	CountByCategory() map[string]int
+	CountBySeverity() map[Severity]int
+	PushSnapshot()
+	History() []ReportSnapshot
 }
@@ -14,7 +14,10 @@
 
 package api
 
-import "github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+import (
+	"context"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+)
 
 const (
 	// SubnetMask defines the default subnet mask for pod addressing - TODO: must be refactored to consider CIDR
@@ -23,10 +26,27 @@ const (
 	VppVNI = 10
 )
 
+// Names of the secondary node indices that can be used in
+// VppCache.RetrieveNodeByIndex().
+const (
+	// GigEIPIndex indexes nodes by their VPP GigE IP address
+	GigEIPIndex = "gige"
+	// HostIPIndex indexes nodes by their host (management) IP address
+	HostIPIndex = "host"
+	// LoopIPIndex indexes nodes by their loop0 (BVI) IP address
+	LoopIPIndex = "loopIP"
+	// LoopMACIndex indexes nodes by their loop0 (BVI) MAC address
+	LoopMACIndex = "loopMAC"
+	// ManIPIndex is an alias for HostIPIndex
+	ManIPIndex = "manIP"
+)
+
 // VppCache defines the operations on the VPP node data store.
 type VppCache interface {
 	CreateNode(ID uint32, nodeName, IPAdr, ManIPAdr string) error
 	RetrieveNode(nodeName string) (*telemetrymodel.Node, error)
+	RetrieveNodeCopy(nodeName string) (*telemetrymodel.Node, error)
+	WaitForNode(ctx context.Context, nodeName string) (*telemetrymodel.Node, error)
 	UpdateNode(ID uint32, nodeName, IPAdr, ManIPAdr string) error
 	DeleteNode(nodeName string) error
 
@@ -34,6 +54,8 @@ type VppCache interface {
 	RetrieveNodeByLoopMacAddr(macAddress string) (*telemetrymodel.Node, error)
 	RetrieveNodeByLoopIPAddr(ipAddress string) (*telemetrymodel.Node, error)
 	RetrieveNodeByGigEIPAddr(ipAddress string) (*telemetrymodel.Node, error)
+	RetrieveNodeByIndex(indexName string, key string) (*telemetrymodel.Node, error)
+	RetrieveNodeByAnyIP(ip string) (*telemetrymodel.Node, error)
 
 	RetrieveAllNodes() []*telemetrymodel.Node
 
@@ -47,7 +69,10 @@ type VppCache interface {
 	SetNodeIPam(nodeName string, nIPam telemetrymodel.IPamEntry) error
 
 	SetSecondaryNodeIndices(node *telemetrymodel.Node) []string
+	ValidateIndexIntegrity() []string
+	RebuildIndexes() []string
 
+	ResetNodeData(nodeName string) error
 	ClearCache()
 	ReinitializeCache()
 }
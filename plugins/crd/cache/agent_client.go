@@ -0,0 +1,87 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AgentClient retrieves data from a Contiv agent. Get returns the body, the
+// status code and the headers of the response to a request for the
+// specified url. An error is returned only if no response could be
+// received or its body could not be read completely, in which case the
+// part of the body received is returned along with the error; a response
+// with a non-2xx status code is not an error.
+type AgentClient interface {
+	Get(ctx context.Context, url string) ([]byte, int, http.Header, error)
+}
+
+// httpAgentClient is the default AgentClient that retrieves data from
+// agents over HTTP.
+type httpAgentClient struct {
+	client http.Client
+}
+
+// Get performs an HTTP GET request for the specified url.
+func (c *httpAgentClient) Get(ctx context.Context, url string) ([]byte, int, http.Header, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	defer res.Body.Close()
+
+	// The body is read even for error responses, so that the connection
+	// can be reused
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return b, res.StatusCode, res.Header, err
+	}
+	return b, res.StatusCode, res.Header, nil
+}
+
+// retryAfterDelay returns the delay requested in the Retry-After header
+// value of a response, which is either a number of seconds or an HTTP date.
+// defaultDelay is returned if the value is missing or invalid; a date in the
+// past results in no delay.
+func retryAfterDelay(value string, now time.Time, defaultDelay time.Duration) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultDelay
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return defaultDelay
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+		return 0
+	}
+	return defaultDelay
+}
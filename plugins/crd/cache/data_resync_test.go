@@ -153,7 +153,7 @@ func TestDataResync(t *testing.T) {
 	drd.log = logrus.DefaultLogger()
 	drd.log.SetLevel(logging.ErrorLevel)
 	drd.log.SetOutput(drd.logWriter)
-	drd.report = datastore.NewSimpleReport(drd.log)
+	drd.report = datastore.NewSimpleReport(drd.log, 0)
 	drd.report.Output = &nullWriter{}
 
 	drd.processor = &mockProcessor{}
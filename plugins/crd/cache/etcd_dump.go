@@ -0,0 +1,99 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	nodeinfomodel "github.com/contiv/vpp/plugins/contiv/model/node"
+	"github.com/contiv/vpp/plugins/crd/api"
+	nodemodel "github.com/contiv/vpp/plugins/ksr/model/node"
+	podmodel "github.com/contiv/vpp/plugins/ksr/model/pod"
+	"github.com/golang/protobuf/proto"
+	"io"
+	"strings"
+)
+
+// EtcdDumpRecord is a single key-value record in an etcd dump. The key is
+// either relative to the agent's key prefix (e.g. 'allocatedIDs/1') or the
+// full etcd key; the value is the JSON encoding of the record's data.
+type EtcdDumpRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// etcdDumpKeyVal makes an etcd dump record usable as a datasync key-value
+// pair, so that it can be cached the same way as resync data.
+type etcdDumpKeyVal struct {
+	key   string
+	value json.RawMessage
+}
+
+func (kv *etcdDumpKeyVal) GetKey() string {
+	return kv.key
+}
+
+func (kv *etcdDumpKeyVal) GetRevision() int64 {
+	return 0
+}
+
+func (kv *etcdDumpKeyVal) GetValue(value proto.Message) error {
+	return json.Unmarshal(kv.value, value)
+}
+
+// LoadFromEtcdDump populates the VPP and K8s caches from an etcd dump read
+// from r instead of collecting the data from the agents. The dump is a JSON
+// array of EtcdDumpRecords holding node info (allocatedIDs/), K8s node and
+// K8s pod records; records with other keys are ignored. Records that can
+// not be cached are reported to the global report and the data from all
+// other records is loaded.
+func (ctc *ContivTelemetryCache) LoadFromEtcdDump(r io.Reader) error {
+	records := make([]EtcdDumpRecord, 0)
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return fmt.Errorf("failed to parse etcd dump: %s", err)
+	}
+
+	errCnt := 0
+	for _, record := range records {
+		var err error
+		switch {
+		case strings.Contains(record.Key, nodeinfomodel.AllocatedIDsKeyPrefix):
+			key := record.Key[strings.Index(record.Key, nodeinfomodel.AllocatedIDsKeyPrefix):]
+			err = ctc.parseAndCacheNodeInfoData(key, &etcdDumpKeyVal{key, record.Value})
+
+		case strings.Contains(record.Key, podmodel.KeyPrefix()):
+			key := record.Key[strings.Index(record.Key, podmodel.KeyPrefix()):]
+			err = ctc.parseAndCachePodData(key, &etcdDumpKeyVal{key, record.Value})
+
+		case strings.Contains(record.Key, nodemodel.KeyPrefix()):
+			key := record.Key[strings.Index(record.Key, nodemodel.KeyPrefix()):]
+			err = ctc.parseAndCacheNodeData(key, &etcdDumpKeyVal{key, record.Value})
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			errCnt++
+			ctc.Report.AppendToNodeReport(api.GlobalMsg, err.Error())
+		}
+	}
+
+	if errCnt > 0 {
+		return fmt.Errorf("failed to load %d of %d etcd dump records", errCnt, len(records))
+	}
+	return nil
+}
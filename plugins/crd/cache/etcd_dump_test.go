@@ -0,0 +1,86 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"github.com/contiv/vpp/plugins/crd/api"
+	"github.com/contiv/vpp/plugins/crd/datastore"
+	"github.com/ligato/cn-infra/logging/logrus"
+	"github.com/onsi/gomega"
+	"strings"
+	"testing"
+)
+
+const etcdDump = `[
+	{"key": "allocatedIDs/1", "value": {"id": 1, "name": "k8s-master",
+		"ip_address": "192.168.16.1/24", "management_ip_address": "10.20.0.2"}},
+	{"key": "/vnf-agent/contiv-ksr/allocatedIDs/2", "value": {"id": 2, "name": "k8s-worker1",
+		"ip_address": "192.168.16.2/24", "management_ip_address": "10.20.0.10"}},
+	{"key": "k8s/node/k8s-master", "value": {"name": "k8s-master", "pod_CIDR": "10.0.0.0/24",
+		"addresses": [{"type": 3, "address": "10.20.0.2"}]}},
+	{"key": "k8s/pod/kube-dns-86f4d74b45-tx7td/namespace/kube-system", "value": {
+		"name": "kube-dns-86f4d74b45-tx7td", "namespace": "kube-system",
+		"ip_address": "10.1.1.3", "host_ip_address": "10.20.0.2"}},
+	{"key": "/vnf-agent/contiv-ksr/k8s/pod/nginx-768979984b-7lgkl/namespace/default", "value": {
+		"name": "nginx-768979984b-7lgkl", "namespace": "default",
+		"ip_address": "10.1.2.2", "host_ip_address": "10.20.0.10"}},
+	{"key": "k8s/namespace/default", "value": {"name": "default"}}
+]`
+
+func TestContivTelemetryCache_LoadFromEtcdDump(t *testing.T) {
+	gomega.RegisterTestingT(t)
+
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+		Report:   datastore.NewSimpleReport(logrus.DefaultLogger(), 0),
+		Log:      logrus.DefaultLogger(),
+	}
+	gomega.Expect(ctc.LoadFromEtcdDump(strings.NewReader(etcdDump))).To(gomega.Succeed())
+	gomega.Expect(ctc.Report.RetrieveReport()).To(gomega.BeEmpty())
+
+	gomega.Expect(ctc.VppCache.RetrieveAllNodes()).To(gomega.HaveLen(2))
+	node, err := ctc.VppCache.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.ID).To(gomega.Equal(uint32(2)))
+	gomega.Expect(node.IPAddr).To(gomega.Equal("192.168.16.2/24"))
+	gomega.Expect(node.ManIPAddr).To(gomega.Equal("10.20.0.10"))
+
+	k8sNode, err := ctc.K8sCache.RetrieveK8sNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(k8sNode.Pod_CIDR).To(gomega.Equal("10.0.0.0/24"))
+
+	gomega.Expect(ctc.K8sCache.RetrieveAllPods()).To(gomega.HaveLen(2))
+	pod, err := ctc.K8sCache.RetrievePod("nginx-768979984b-7lgkl", "default")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(pod.IPAddress).To(gomega.Equal("10.1.2.2"))
+	gomega.Expect(pod.HostIPAddress).To(gomega.Equal("10.20.0.10"))
+
+	// Invalid records are reported, valid records are still loaded
+	ctc.ReinitializeCache()
+	err = ctc.LoadFromEtcdDump(strings.NewReader(`[
+		{"key": "allocatedIDs/1", "value": {"id": 1, "name": "k8s-master"}},
+		{"key": "allocatedIDs/2", "value": {"id": 2, "name": "k8s-worker1",
+			"ip_address": "192.168.16.2/24", "management_ip_address": "10.20.0.10"}}
+	]`))
+	gomega.Expect(err).To(gomega.MatchError("failed to load 1 of 2 etcd dump records"))
+	gomega.Expect(ctc.Report.RetrieveReport()[api.GlobalMsg]).To(gomega.HaveLen(1))
+	gomega.Expect(ctc.VppCache.RetrieveAllNodes()).To(gomega.HaveLen(1))
+
+	// Malformed dump
+	err = ctc.LoadFromEtcdDump(strings.NewReader(`{"key": "allocatedIDs/1"}`))
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+}
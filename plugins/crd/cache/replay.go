@@ -0,0 +1,165 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// replayNodeFile is the name of the file in a node's replay directory
+	// that holds the node's ID and addresses.
+	replayNodeFile = "node.json"
+)
+
+// replayDTOs lists the files in a node's replay directory that hold the
+// recorded agent responses, and the DTO type of each of them.
+var replayDTOs = []struct {
+	file   string
+	newDTO func() interface{}
+}{
+	{"liveness.json", func() interface{} { return &telemetrymodel.NodeLiveness{} }},
+	{"interfaces.json", func() interface{} { return &telemetrymodel.NodeInterfaces{} }},
+	{"bridge_domains.json", func() interface{} { return &telemetrymodel.NodeBridgeDomains{} }},
+	{"l2_fibs.json", func() interface{} { return &telemetrymodel.NodeL2FibTable{} }},
+	{"arps.json", func() interface{} { return &telemetrymodel.NodeIPArpTable{} }},
+	{"static_routes.json", func() interface{} { return &telemetrymodel.NodeStaticRoutes{} }},
+	{"ipam.json", func() interface{} { return &telemetrymodel.IPamEntry{} }},
+}
+
+// ReplayNode holds the ID and addresses of a node recorded for replay.
+type ReplayNode struct {
+	ID        uint32 `json:"id"`
+	Name      string `json:"name"`
+	IPAddr    string `json:"ip_address"`
+	ManIPAddr string `json:"management_ip_address"`
+}
+
+// ReplaySource reads agent responses recorded in a directory, so that they
+// can be processed without contacting the agents. The directory contains
+// a subdirectory for each node, named after the node. A node subdirectory
+// holds one JSON file per DTO type (liveness.json, interfaces.json,
+// bridge_domains.json, l2_fibs.json, arps.json, static_routes.json and
+// ipam.json) with the agent response for that DTO, and optionally a
+// node.json file with the node's ID and addresses. Missing DTO files are
+// skipped.
+type ReplaySource struct {
+	Dir string
+}
+
+// NewReplaySource creates a new ReplaySource for the specified directory.
+func NewReplaySource(dir string) *ReplaySource {
+	return &ReplaySource{Dir: dir}
+}
+
+// Nodes returns the nodes recorded in the replay directory, ordered by
+// name. Nodes without a node.json file only have their name set. The node
+// name in a node.json file, if set, must be the name of the node's
+// subdirectory.
+func (rs *ReplaySource) Nodes() ([]ReplayNode, error) {
+	files, err := ioutil.ReadDir(rs.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]ReplayNode, 0)
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		node := ReplayNode{}
+		found, err := rs.readFile(filepath.Join(f.Name(), replayNodeFile), &node)
+		if err != nil {
+			return nil, err
+		}
+		if found && node.Name != "" && node.Name != f.Name() {
+			return nil, fmt.Errorf("replay file %s names node %s, expected node %s",
+				filepath.Join(f.Name(), replayNodeFile), node.Name, f.Name())
+		}
+		node.Name = f.Name()
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+// NodeDTOs returns the DTOs recorded for the specified node.
+func (rs *ReplaySource) NodeDTOs(nodeName string) ([]*NodeDTO, error) {
+	dtos := make([]*NodeDTO, 0, len(replayDTOs))
+	for _, rd := range replayDTOs {
+		nodeInfo := rd.newDTO()
+		found, err := rs.readFile(filepath.Join(nodeName, rd.file), nodeInfo)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			dtos = append(dtos, &NodeDTO{NodeName: nodeName, NodeInfo: nodeInfo})
+		}
+	}
+	return dtos, nil
+}
+
+// readFile unmarshals the JSON file at the specified path relative to the
+// replay directory into data. It returns false if the file does not exist.
+func (rs *ReplaySource) readFile(path string, data interface{}) (bool, error) {
+	b, err := ioutil.ReadFile(filepath.Join(rs.Dir, path))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, data); err != nil {
+		return false, fmt.Errorf("failed to parse replay file %s: %s", path, err)
+	}
+	return true, nil
+}
+
+// LoadFromReplay stores the agent responses recorded in the specified
+// directory (see ReplaySource) into the cache in the same way as data
+// collected from the agents. Nodes that are not in the cache yet are
+// created. LoadFromReplay must not be used while data collection is
+// running.
+func (ctc *ContivTelemetryCache) LoadFromReplay(dir string) error {
+	rs := NewReplaySource(dir)
+	nodes, err := rs.Nodes()
+	if err != nil {
+		return err
+	}
+
+	dtoList := make([]*NodeDTO, 0)
+	for _, node := range nodes {
+		if _, err := ctc.VppCache.RetrieveNode(node.Name); err != nil {
+			if err := ctc.VppCache.CreateNode(node.ID, node.Name, node.IPAddr, node.ManIPAddr); err != nil {
+				return err
+			}
+		}
+		dtos, err := rs.NodeDTOs(node.Name)
+		if err != nil {
+			return err
+		}
+		dtoList = append(dtoList, dtos...)
+	}
+
+	ctc.setNodeData(dtoList)
+	return nil
+}
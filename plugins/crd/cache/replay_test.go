@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"encoding/json"
+	"github.com/contiv/vpp/plugins/crd/datastore"
+	"github.com/contiv/vpp/plugins/crd/testdata"
+	"github.com/ligato/cn-infra/logging/logrus"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContivTelemetryCache_LoadFromReplay(t *testing.T) {
+	gomega.RegisterTestingT(t)
+
+	recorded := datastore.NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(recorded)).To(gomega.Succeed())
+
+	dir, err := ioutil.TempDir("", "replay")
+	gomega.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+
+	// Record the sample data
+	for _, node := range recorded.RetrieveAllNodes() {
+		nodeDir := filepath.Join(dir, node.Name)
+		gomega.Expect(os.Mkdir(nodeDir, 0755)).To(gomega.Succeed())
+		for file, data := range map[string]interface{}{
+			replayNodeFile: ReplayNode{
+				ID:        node.ID,
+				Name:      node.Name,
+				IPAddr:    node.IPAddr,
+				ManIPAddr: node.ManIPAddr,
+			},
+			"liveness.json":       node.NodeLiveness,
+			"interfaces.json":     node.NodeInterfaces,
+			"bridge_domains.json": node.NodeBridgeDomains,
+			"l2_fibs.json":        node.NodeL2Fibs,
+			"arps.json":           node.NodeIPArp,
+		} {
+			buf, err := json.Marshal(data)
+			gomega.Expect(err).To(gomega.BeNil())
+			gomega.Expect(ioutil.WriteFile(filepath.Join(nodeDir, file), buf, 0644)).To(gomega.Succeed())
+		}
+	}
+
+	// Replay the recorded data
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+		Report:   datastore.NewSimpleReport(logrus.DefaultLogger(), 0),
+	}
+	gomega.Expect(ctc.LoadFromReplay(dir)).To(gomega.Succeed())
+	gomega.Expect(ctc.Report.RetrieveReport()).To(gomega.BeEmpty())
+
+	gomega.Expect(ctc.VppCache.RetrieveAllNodes()).To(gomega.HaveLen(len(recorded.RetrieveAllNodes())))
+	for _, exp := range recorded.RetrieveAllNodes() {
+		node, err := ctc.VppCache.RetrieveNode(exp.Name)
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(node.ID).To(gomega.Equal(exp.ID))
+		gomega.Expect(node.IPAddr).To(gomega.Equal(exp.IPAddr))
+		gomega.Expect(node.ManIPAddr).To(gomega.Equal(exp.ManIPAddr))
+		gomega.Expect(node.NodeLiveness).To(gomega.Equal(exp.NodeLiveness))
+		gomega.Expect(node.NodeInterfaces).To(gomega.Equal(exp.NodeInterfaces))
+		gomega.Expect(node.NodeBridgeDomains).To(gomega.Equal(exp.NodeBridgeDomains))
+		gomega.Expect(node.NodeL2Fibs).To(gomega.Equal(exp.NodeL2Fibs))
+		gomega.Expect(node.NodeIPArp).To(gomega.Equal(exp.NodeIPArp))
+	}
+
+	// Malformed replay file
+	err = ioutil.WriteFile(filepath.Join(dir, "k8s-master", "arps.json"), []byte("{"), 0644)
+	gomega.Expect(err).To(gomega.BeNil())
+	err = ctc.LoadFromReplay(dir)
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(err.Error()).To(gomega.ContainSubstring("arps.json"))
+
+	// Node name in node.json does not match the node's directory
+	buf, err := json.Marshal(ReplayNode{Name: "k8s-worker9"})
+	gomega.Expect(err).To(gomega.BeNil())
+	err = ioutil.WriteFile(filepath.Join(dir, "k8s-master", replayNodeFile), buf, 0644)
+	gomega.Expect(err).To(gomega.BeNil())
+	err = ctc.LoadFromReplay(dir)
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(err.Error()).To(gomega.ContainSubstring("k8s-worker9"))
+
+	// Missing replay directory
+	gomega.Expect(ctc.LoadFromReplay(filepath.Join(dir, "missing"))).NotTo(gomega.Succeed())
+}
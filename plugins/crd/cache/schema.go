@@ -0,0 +1,318 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"math"
+	"sort"
+)
+
+// jsonSchema is the subset of JSON Schema used to validate agent responses
+// before they are unmarshalled into DTOs. Supported keywords are 'type',
+// 'properties' (properties not listed are allowed), 'items' and
+// 'additionalProperties' (the schema of the values of all properties not
+// listed in 'properties'). A null value is valid for any schema.
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Items                *jsonSchema            `json:"items"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties"`
+}
+
+const livenessSchema = `{
+	"type": "object",
+	"properties": {
+		"build_version": {"type": "string"},
+		"build_date": {"type": "string"},
+		"state": {"type": "integer"},
+		"start_time": {"type": "integer"},
+		"last_change": {"type": "integer"},
+		"last_update": {"type": "integer"},
+		"commit_hash": {"type": "string"}
+	}
+}`
+
+const interfacesSchema = `{
+	"type": "object",
+	"additionalProperties": {
+		"type": "object",
+		"properties": {
+			"interface": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"type": {"type": "integer"},
+					"enabled": {"type": "boolean"},
+					"phys_address": {"type": "string"},
+					"mtu": {"type": "integer"},
+					"vrf": {"type": "integer"},
+					"ip_addresses": {"type": "array", "items": {"type": "string"}},
+					"vxlan": {
+						"type": "object",
+						"properties": {
+							"src_address": {"type": "string"},
+							"dst_address": {"type": "string"},
+							"vni": {"type": "integer"}
+						}
+					},
+					"tap": {
+						"type": "object",
+						"properties": {
+							"version": {"type": "integer"},
+							"host_if_name": {"type": "string"}
+						}
+					}
+				}
+			},
+			"interface_meta": {
+				"type": "object",
+				"properties": {
+					"sw_if_index": {"type": "integer"},
+					"tag": {"type": "string"},
+					"internal_name": {"type": "string"}
+				}
+			}
+		}
+	}
+}`
+
+const bridgeDomainsSchema = `{
+	"type": "object",
+	"additionalProperties": {
+		"type": "object",
+		"properties": {
+			"bridge_domain": {
+				"type": "object",
+				"properties": {
+					"interfaces": {
+						"type": "array",
+						"items": {
+							"type": "object",
+							"properties": {
+								"name": {"type": "string"},
+								"bridged_virtual_interface": {"type": "boolean"},
+								"split_horizon_group": {"type": "integer"}
+							}
+						}
+					},
+					"name": {"type": "string"},
+					"forward": {"type": "boolean"}
+				}
+			},
+			"bridge_domain_meta": {
+				"type": "object",
+				"properties": {
+					"bridge_domain_id": {"type": "integer"},
+					"bridge_domain_id_to_name": {
+						"type": "object",
+						"additionalProperties": {"type": "string"}
+					}
+				}
+			}
+		}
+	}
+}`
+
+const l2FibsSchema = `{
+	"type": "object",
+	"additionalProperties": {
+		"type": "object",
+		"properties": {
+			"fib": {
+				"type": "object",
+				"properties": {
+					"bridge_domain": {"type": "string"},
+					"outgoing_interface": {"type": "string"},
+					"phys_address": {"type": "string"},
+					"static_config": {"type": "boolean"},
+					"bridged_virtual_interface": {"type": "boolean"}
+				}
+			},
+			"fib_meta": {
+				"type": "object",
+				"properties": {
+					"bridge_domain_id": {"type": "integer"},
+					"outgoing_interface_sw_if_idx": {"type": "integer"}
+				}
+			}
+		}
+	}
+}`
+
+const arpsSchema = `{
+	"type": "array",
+	"items": {
+		"type": "object",
+		"properties": {
+			"Arp": {
+				"type": "object",
+				"properties": {
+					"interface": {"type": "string"},
+					"ip_address": {"type": "string"},
+					"phys_address": {"type": "string"},
+					"static": {"type": "boolean"}
+				}
+			},
+			"Meta": {
+				"type": "object",
+				"properties": {
+					"SwIfIndex": {"type": "integer"}
+				}
+			}
+		}
+	}
+}`
+
+// dtoSchemas holds the parsed schemas of the DTO types whose agent
+// responses are validated, keyed by the name of the DTO type.
+var dtoSchemas = map[string]*jsonSchema{
+	"NodeLiveness":      mustParseSchema(livenessSchema),
+	"NodeInterfaces":    mustParseSchema(interfacesSchema),
+	"NodeBridgeDomains": mustParseSchema(bridgeDomainsSchema),
+	"NodeL2FibTable":    mustParseSchema(l2FibsSchema),
+	"NodeIPArpTable":    mustParseSchema(arpsSchema),
+}
+
+// mustParseSchema parses an embedded schema; it panics if the schema is
+// not valid JSON.
+func mustParseSchema(schema string) *jsonSchema {
+	s := &jsonSchema{}
+	if err := json.Unmarshal([]byte(schema), s); err != nil {
+		panic(fmt.Sprintf("invalid embedded schema: %s", err))
+	}
+	return s
+}
+
+// validateDTOSchema validates the JSON data received from an agent against
+// the schema of the DTO type of nodeInfo. Data for DTO types without a
+// schema is not validated.
+func validateDTOSchema(nodeInfo interface{}, data []byte) error {
+	var schema *jsonSchema
+	switch nodeInfo.(type) {
+	case *telemetrymodel.NodeLiveness:
+		schema = dtoSchemas["NodeLiveness"]
+	case *telemetrymodel.NodeInterfaces:
+		schema = dtoSchemas["NodeInterfaces"]
+	case *telemetrymodel.NodeBridgeDomains:
+		schema = dtoSchemas["NodeBridgeDomains"]
+	case *telemetrymodel.NodeL2FibTable:
+		schema = dtoSchemas["NodeL2FibTable"]
+	case *telemetrymodel.NodeIPArpTable:
+		schema = dtoSchemas["NodeIPArpTable"]
+	default:
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %s", err)
+	}
+	return schema.validate(value, "")
+}
+
+// validate checks the value against the schema. path is the path of the
+// value in the validated document.
+func (s *jsonSchema) validate(value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+	if s.Type != "" && !hasJSONType(value, s.Type) {
+		return fmt.Errorf("schema violation at field %s: expected %s, got %s",
+			fieldPath(path), s.Type, jsonTypeOf(value))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			propSchema, ok := s.Properties[key]
+			if !ok {
+				propSchema = s.AdditionalProperties
+			}
+			if propSchema == nil {
+				continue
+			}
+			if err := propSchema.validate(v[key], joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasJSONType returns true if the decoded JSON value is of the specified
+// JSON Schema type.
+func hasJSONType(value interface{}, jsonType string) bool {
+	switch jsonType {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeOf(value) == jsonType
+	}
+}
+
+// jsonTypeOf returns the JSON Schema type of the decoded JSON value.
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
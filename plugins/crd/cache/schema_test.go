@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cache
+
+import (
+	"encoding/json"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"github.com/contiv/vpp/plugins/crd/datastore"
+	"github.com/contiv/vpp/plugins/crd/testdata"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestValidateDTOSchema(t *testing.T) {
+	gomega.RegisterTestingT(t)
+
+	vppCache := datastore.NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(vppCache)).To(gomega.Succeed())
+
+	// The sample data of all nodes conforms to the schemas
+	for _, node := range vppCache.RetrieveAllNodes() {
+		dtos := []struct {
+			nodeInfo interface{}
+			data     interface{}
+		}{
+			{&telemetrymodel.NodeLiveness{}, node.NodeLiveness},
+			{&telemetrymodel.NodeInterfaces{}, node.NodeInterfaces},
+			{&telemetrymodel.NodeBridgeDomains{}, node.NodeBridgeDomains},
+			{&telemetrymodel.NodeL2FibTable{}, node.NodeL2Fibs},
+			{&telemetrymodel.NodeIPArpTable{}, node.NodeIPArp},
+		}
+		for _, dto := range dtos {
+			b, err := json.Marshal(dto.data)
+			gomega.Expect(err).To(gomega.BeNil())
+			gomega.Expect(validateDTOSchema(dto.nodeInfo, b)).To(gomega.Succeed())
+		}
+	}
+
+	// Wrong-typed fields
+	err := validateDTOSchema(&telemetrymodel.NodeLiveness{}, []byte(`{"build_version": "v1", "state": "up"}`))
+	gomega.Expect(err).To(gomega.MatchError("schema violation at field state: expected integer, got string"))
+
+	err = validateDTOSchema(&telemetrymodel.NodeInterfaces{},
+		[]byte(`{"1": {"interface": {"name": "vxlanBVI", "mtu": 1500.5}}}`))
+	gomega.Expect(err).To(gomega.MatchError("schema violation at field 1.interface.mtu: expected integer, got number"))
+
+	err = validateDTOSchema(&telemetrymodel.NodeIPArpTable{},
+		[]byte(`[{"Arp": {"static": true}}, {"Arp": {"static": "yes"}}]`))
+	gomega.Expect(err).To(gomega.MatchError("schema violation at field [1].Arp.static: expected boolean, got string"))
+
+	err = validateDTOSchema(&telemetrymodel.NodeL2FibTable{}, []byte(`[]`))
+	gomega.Expect(err).To(gomega.MatchError("schema violation at field (root): expected object, got array"))
+
+	// Null values and unknown fields are allowed
+	err = validateDTOSchema(&telemetrymodel.NodeBridgeDomains{},
+		[]byte(`{"1": {"bridge_domain": null, "unknown": 1}}`))
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// DTO types without a schema are not validated
+	gomega.Expect(validateDTOSchema(&telemetrymodel.IPamEntry{}, []byte(`"bogus"`))).To(gomega.Succeed())
+}
@@ -16,6 +16,8 @@
 package cache
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/contiv/vpp/plugins/crd/api"
@@ -23,9 +25,15 @@ import (
 	nodemodel "github.com/contiv/vpp/plugins/ksr/model/node"
 	"github.com/ligato/cn-infra/datasync"
 	"github.com/ligato/cn-infra/logging"
-	"io/ioutil"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
+	"golang.org/x/time/rate"
+	"io"
+	"math/rand"
+	"net"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,11 +50,54 @@ const (
 	ipamURL            = "/contiv/v1/ipam"
 	arpURL             = "/vpp/dump/v1/arps"
 	staticRouteURL     = "/vpp/dump/v1/routes"
+	batchURL           = "/telemetry/all"
+	controlPlaneNS     = "kube-system"
 	clientTimeout      = 10 // HTTP client timeout, in seconds
 	collectionInterval = 1  // data collection interval, in minutes
+	cycleTimeout       = 30 // data collection cycle timeout, in seconds
+	idleConnTimeout    = 90 // idle agent connection timeout, in seconds
+	retryAfterDefault  = 1  // retry delay for rate-limited requests without Retry-After, in seconds
+	maxRetryAfterTries = 5  // max number of retries of a rate-limited request
 
 )
 
+// controlPlanePodPrefixes are the name prefixes of the control plane pods
+// that run only on the master node
+var controlPlanePodPrefixes = []string{"kube-apiserver-", "etcd-"}
+
+// dtoPath associates a DTO type name with the agent URL path it is
+// collected from.
+type dtoPath struct {
+	name string
+	url  string
+}
+
+// URLPaths holds the paths of the agent REST endpoints from which node data
+// is collected. Paths that are left empty are set to their defaults when the
+// cache is initialized.
+type URLPaths struct {
+	Liveness      string
+	Interfaces    string
+	BridgeDomains string
+	L2Fibs        string
+	Arps          string
+	StaticRoutes  string
+	Ipam          string
+	Batch         string
+}
+
+// batchDTO is the combined payload served by the agent batch endpoint.
+// Sections missing in the payload are reported as failed DTOs.
+type batchDTO struct {
+	Liveness      *telemetrymodel.NodeLiveness      `json:"liveness"`
+	Interfaces    *telemetrymodel.NodeInterfaces    `json:"interfaces"`
+	BridgeDomains *telemetrymodel.NodeBridgeDomains `json:"bridge_domains"`
+	L2Fibs        *telemetrymodel.NodeL2FibTable    `json:"l2_fibs"`
+	Arps          *telemetrymodel.NodeIPArpTable    `json:"arps"`
+	StaticRoutes  *telemetrymodel.NodeStaticRoutes  `json:"static_routes"`
+	Ipam          *telemetrymodel.IPamEntry         `json:"ipam"`
+}
+
 // ContivTelemetryCache is used for a in-memory storage of K8s State data
 // The cache processes K8s State data updates and RESYNC events through Update()
 // and Resync() APIs, respectively.
@@ -61,12 +112,98 @@ type ContivTelemetryCache struct {
 	Report           api.Report
 	ControllerReport api.ContivTelemetryControllerReport
 
+	// CycleTimeout is the overall deadline for collecting data from all
+	// agents in one data collection cycle. DTOs not received by then are
+	// reported as failed and validation proceeds with the data at hand.
+	CycleTimeout time.Duration
+
+	// URLPaths are the agent REST endpoint paths used for data collection.
+	// They can be overridden for agent versions that serve data from
+	// different paths.
+	URLPaths URLPaths
+
+	// PollJitter is the fraction (0 to 1) of the collection interval by
+	// which the start of each data collection cycle is randomly delayed,
+	// so that multiple pollers do not hit the agents at the same time.
+	// Jitter is disabled when set to 0.
+	PollJitter float64
+
+	// LivenessOnlyFirst enables incremental data collection: the liveness
+	// of each node is collected first and the rest of the node's data is
+	// collected only if the node's LastUpdate time has advanced since the
+	// previous cycle. Data from the previous cycle is reused for unchanged
+	// nodes.
+	LivenessOnlyFirst bool
+
+	// BatchCollect enables collection of all node data in a single request
+	// to the agent batch endpoint. If the endpoint is not available (404),
+	// the data is collected from the individual endpoints instead.
+	// LivenessOnlyFirst has no effect in this mode.
+	BatchCollect bool
+
+	// ValidateSchemas enables the validation of the data received from the
+	// individual agent endpoints against the JSON schema of its DTO type
+	// before the data is unmarshalled. Schema violations are reported for
+	// the node and the DTO is treated as failed.
+	ValidateSchemas bool
+
+	// AgentClient, if set, is used to collect data from agents instead of
+	// the default HTTP client.
+	AgentClient AgentClient
+
+	// MaxRequestsPerSecond caps the rate of requests sent to all agents,
+	// MaxNodeRequestsPerSecond caps the rate of requests sent to a single
+	// agent. Requests wait for their turn until the data collection cycle
+	// times out. Rate limiting is disabled when set to 0.
+	MaxRequestsPerSecond     float64
+	MaxNodeRequestsPerSecond float64
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept
+	// open to each agent for reuse in subsequent requests. Defaults to the
+	// number of DTOs collected from an agent.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the time after which an idle connection to an
+	// agent is closed.
+	IdleConnTimeout time.Duration
+
+	// NodeURLResolver, if set, returns the base URL (scheme, host and port)
+	// of the agent on the node, e.g. to collect data through a service
+	// proxy or gateway. By default, agents are reached at the node's
+	// management IP address and the agent port.
+	NodeURLResolver func(node *telemetrymodel.Node) string
+
+	// OnRequest, if set, is invoked before each HTTP request to an agent.
+	OnRequest func(nodeName, url string)
+	// OnResponse, if set, is invoked after each HTTP request to an agent
+	// completes. statusCode is 0 if no HTTP response was received.
+	// Both hooks may be invoked concurrently from multiple goroutines.
+	OnResponse func(nodeName, url string, statusCode int, latency time.Duration, err error)
+
 	nodeResponseChannel  chan *NodeDTO
 	dsUpdateChannel      chan interface{}
 	dtoList              []*NodeDTO
+	dtoPresence          map[string]map[string]bool
+	prevNodeDTOs         map[string][]*NodeDTO
 	ticker               *time.Ticker
+	cycleTimer           *time.Timer
+	jitterTimer          *time.Timer
+	jitterRand           *rand.Rand
 	collectionInterval   time.Duration
 	httpClientTimeout    time.Duration
+	transport            *http.Transport
+	cycleCtx             context.Context
+	cycleCancel          context.CancelFunc
+	limiterMtx           sync.Mutex
+	rateLimiter          *rate.Limiter
+	nodeRateLimiters     map[string]*rate.Limiter
+	collectionErrMtx     sync.Mutex
+	collectionErrors     map[string]int
+	syncMtx              sync.Mutex
+	syncedCh             chan struct{}
+	collectNodesChannel  chan *collectNodesRequest
+	cycleNodeNames       map[string]bool
+	cycleNumNodes        int
+	cycleResult          chan error
 	agentPort            string
 	validationInProgress bool
 	databaseVersion      uint32
@@ -84,6 +221,27 @@ type NodeDTO struct {
 	NodeInfo interface{}
 	err      error
 	version  uint32
+	url      string
+}
+
+// dtoError is an error in the data received for a DTO whose message fully
+// describes the problem. It is recorded in the report as is, when the DTO
+// is processed by the cache thread; an error shared by several DTOs (e.g.
+// a failed batch request) is recorded once.
+type dtoError struct {
+	msg string
+}
+
+func (e *dtoError) Error() string {
+	return e.msg
+}
+
+// collectNodesRequest is a request to run a data collection and validation
+// cycle limited to a subset of nodes (see CollectNodes). The outcome of the
+// request is sent to result.
+type collectNodesRequest struct {
+	nodeNames []string
+	result    chan error
 }
 
 // Init initializes policy cache.
@@ -105,9 +263,40 @@ func (ctc *ContivTelemetryCache) init() {
 
 	ctc.nodeResponseChannel = make(chan *NodeDTO)
 	ctc.dsUpdateChannel = make(chan interface{})
+	ctc.collectNodesChannel = make(chan *collectNodesRequest)
 	ctc.dtoList = make([]*NodeDTO, 0)
+	ctc.dtoPresence = make(map[string]map[string]bool)
 	ctc.ticker = time.NewTicker(ctc.collectionInterval)
 	ctc.databaseVersion = 0
+
+	if ctc.CycleTimeout == 0 {
+		ctc.CycleTimeout = cycleTimeout * time.Second
+	}
+	ctc.cycleTimer = time.NewTimer(ctc.CycleTimeout)
+	ctc.cycleTimer.Stop()
+	ctc.cycleCtx = context.Background()
+
+	ctc.URLPaths.setDefaults()
+
+	ctc.jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	ctc.jitterTimer = time.NewTimer(0)
+	ctc.jitterTimer.Stop()
+
+	if ctc.MaxIdleConnsPerHost == 0 {
+		ctc.MaxIdleConnsPerHost = numDTOs
+	}
+	if ctc.IdleConnTimeout == 0 {
+		ctc.IdleConnTimeout = idleConnTimeout * time.Second
+	}
+	ctc.transport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   ctc.httpClientTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConnsPerHost: ctc.MaxIdleConnsPerHost,
+		IdleConnTimeout:     ctc.IdleConnTimeout,
+	}
 }
 
 // ClearCache with clear all Contiv Telemetry cache data except for the
@@ -124,6 +313,276 @@ func (ctc *ContivTelemetryCache) ReinitializeCache() {
 	ctc.VppCache.ReinitializeCache()
 	ctc.K8sCache.ReinitializeCache()
 	ctc.Report.Clear()
+	ctc.prevNodeDTOs = nil
+}
+
+// GetNodeByPodName returns the VPP node hosting the pod with the given name
+// in the given namespace. The node is looked up by the pod's host IP
+// address.
+func (ctc *ContivTelemetryCache) GetNodeByPodName(podName, namespace string) (*telemetrymodel.Node, error) {
+	pod, err := ctc.K8sCache.RetrievePod(podName, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("pod %s in namespace %s not found", podName, namespace)
+	}
+
+	node, err := ctc.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("node with host IP address %s hosting pod %s in namespace %s not found",
+			pod.HostIPAddress, podName, namespace)
+	}
+	return node, nil
+}
+
+// DetectMasterNode returns the name of the master node, i.e. the node whose
+// management IP address hosts the control plane pods (kube-apiserver,
+// etcd). An error is returned if no node or more than one node hosts
+// control plane pods.
+func (ctc *ContivTelemetryCache) DetectMasterNode() (string, error) {
+	candidates := make([]string, 0)
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		if ctc.hostsControlPlanePods(node) {
+			candidates = append(candidates, node.Name)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("master node not found: no node hosts control plane pods")
+	case 1:
+		return candidates[0], nil
+	}
+	return "", fmt.Errorf("master node ambiguous: control plane pods hosted on nodes %s",
+		strings.Join(candidates, ", "))
+}
+
+// hostsControlPlanePods returns true if any control plane pod runs on the
+// node's management IP address.
+func (ctc *ContivTelemetryCache) hostsControlPlanePods(node *telemetrymodel.Node) bool {
+	for _, pod := range ctc.K8sCache.RetrievePodsByHostIPAddr(node.ManIPAddr) {
+		if pod.Namespace != controlPlaneNS {
+			continue
+		}
+		for _, prefix := range controlPlanePodPrefixes {
+			if strings.HasPrefix(pod.Name, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WaitForSync blocks until the first full data collection cycle has
+// completed and the cache is Synced, or until ctx is done, in which case
+// the context's error is returned.
+func (ctc *ContivTelemetryCache) WaitForSync(ctx context.Context) error {
+	select {
+	case <-ctc.syncedChan():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// markSynced sets the cache as Synced and releases all WaitForSync callers.
+func (ctc *ContivTelemetryCache) markSynced() {
+	ch := ctc.syncedChan()
+
+	ctc.syncMtx.Lock()
+	defer ctc.syncMtx.Unlock()
+	ctc.Synced = true
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// syncedChan returns the channel that is closed when the cache is first
+// Synced.
+func (ctc *ContivTelemetryCache) syncedChan() chan struct{} {
+	ctc.syncMtx.Lock()
+	defer ctc.syncMtx.Unlock()
+	if ctc.syncedCh == nil {
+		ctc.syncedCh = make(chan struct{})
+	}
+	return ctc.syncedCh
+}
+
+// FilterVppNodes returns the nodes in the cache that satisfy the specified
+// predicate, ordered by node name.
+func (ctc *ContivTelemetryCache) FilterVppNodes(pred func(*telemetrymodel.Node) bool) []*telemetrymodel.Node {
+	nodes := make([]*telemetrymodel.Node, 0)
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		if pred(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// NodesMissingData returns, for each node with incomplete data in the
+// cache, the names of the node data fields that are not set or empty
+// (NodeLiveness, NodeInterfaces, NodeBridgeDomains, NodeL2Fibs and
+// NodeIPArp). Nodes with complete data are not included.
+func (ctc *ContivTelemetryCache) NodesMissingData() map[string][]string {
+	missing := make(map[string][]string)
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		fields := make([]string, 0)
+		if node.NodeLiveness == nil {
+			fields = append(fields, "NodeLiveness")
+		}
+		if len(node.NodeInterfaces) == 0 {
+			fields = append(fields, "NodeInterfaces")
+		}
+		if len(node.NodeBridgeDomains) == 0 {
+			fields = append(fields, "NodeBridgeDomains")
+		}
+		if len(node.NodeL2Fibs) == 0 {
+			fields = append(fields, "NodeL2Fibs")
+		}
+		if len(node.NodeIPArp) == 0 {
+			fields = append(fields, "NodeIPArp")
+		}
+		if len(fields) > 0 {
+			missing[node.Name] = fields
+		}
+	}
+	return missing
+}
+
+// CollectionErrorCounts returns the number of DTOs that could not be
+// collected from each node in the last data collection cycle.
+func (ctc *ContivTelemetryCache) CollectionErrorCounts() map[string]int {
+	ctc.collectionErrMtx.Lock()
+	defer ctc.collectionErrMtx.Unlock()
+
+	counts := make(map[string]int, len(ctc.collectionErrors))
+	for nodeName, cnt := range ctc.collectionErrors {
+		counts[nodeName] = cnt
+	}
+	return counts
+}
+
+// InterfaceTypeHistogram returns the number of interfaces of each type
+// across all nodes in the cache.
+func (ctc *ContivTelemetryCache) InterfaceTypeHistogram() map[interfaces.InterfaceType]int {
+	histogram := make(map[interfaces.InterfaceType]int)
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		for _, intf := range node.NodeInterfaces {
+			histogram[intf.If.IfType]++
+		}
+	}
+	return histogram
+}
+
+// PodTap associates a pod with the VPP tap interface serving it on its host
+// node. Tap and TapIfIndex are empty if no tap interface serving the pod is
+// found; NodeName is empty if the pod's host node is not known.
+type PodTap struct {
+	PodName    string `json:"podName"`
+	Namespace  string `json:"namespace"`
+	IPAddress  string `json:"ipAddress"`
+	NodeName   string `json:"nodeName"`
+	Tap        string `json:"tap"`
+	TapIfIndex uint32 `json:"tapIfIndex"`
+}
+
+// PodTapMapping returns the VPP tap interface serving each pod in the K8s
+// cache. A tap interface on the pod's host node serves the pod if its /32
+// address is the pod IP address or, as the tap addresses are allocated from
+// the node's pod interface subnet, if its /32 address in the pod interface
+// subnet has the same host part as the pod IP address in the node's pod
+// network.
+func (ctc *ContivTelemetryCache) PodTapMapping() []PodTap {
+	mapping := make([]PodTap, 0)
+	for _, pod := range ctc.K8sCache.RetrieveAllPods() {
+		podTap := PodTap{PodName: pod.Name, Namespace: pod.Namespace, IPAddress: pod.IPAddress}
+		if node, err := ctc.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress); err == nil {
+			podTap.NodeName = node.Name
+			if intf := findPodTap(node, net.ParseIP(pod.IPAddress)); intf != nil {
+				podTap.Tap = intf.If.Name
+				podTap.TapIfIndex = intf.IfMeta.SwIfIndex
+			}
+		}
+		mapping = append(mapping, podTap)
+	}
+	return mapping
+}
+
+// findPodTap returns the tap interface on the node that serves the pod with
+// the specified IP address, or nil if there is no such tap interface.
+func findPodTap(node *telemetrymodel.Node, podIP net.IP) *telemetrymodel.NodeInterface {
+	if podIP == nil {
+		return nil
+	}
+
+	var podNet, podIfNet *net.IPNet
+	if node.NodeIPam != nil {
+		_, podNet, _ = net.ParseCIDR(node.NodeIPam.PodNetwork)
+		_, podIfNet, _ = net.ParseCIDR(node.NodeIPam.Config.PodIfIPCIDR)
+	}
+
+	for _, intf := range node.NodeInterfaces {
+		if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE {
+			continue
+		}
+		for _, ipAddr := range intf.If.IPAddresses {
+			tapIP, tapNet, err := net.ParseCIDR(ipAddr)
+			if err != nil {
+				continue
+			}
+			if ones, bits := tapNet.Mask.Size(); ones != bits {
+				continue
+			}
+			if tapIP.Equal(podIP) {
+				return &intf
+			}
+			if podNet != nil && podIfNet != nil && podNet.Contains(podIP) && podIfNet.Contains(tapIP) &&
+				hostPart(podIP, podNet) == hostPart(tapIP, podIfNet) {
+				return &intf
+			}
+		}
+	}
+	return nil
+}
+
+// hostPart returns the host part of the IP address in the network.
+func hostPart(ip net.IP, network *net.IPNet) string {
+	if len(network.Mask) == net.IPv4len {
+		ip = ip.To4()
+	}
+	host := make([]byte, len(ip))
+	for i := range ip {
+		host[i] = ip[i] &^ network.Mask[i]
+	}
+	return fmt.Sprintf("%x", host)
+}
+
+// NodesSnapshot holds the cached data of a subset of nodes and of the pods
+// running on them.
+type NodesSnapshot struct {
+	Nodes []*telemetrymodel.Node `json:"nodes"`
+	Pods  []*telemetrymodel.Pod  `json:"pods"`
+}
+
+// ExportNodesSnapshot serializes the cached data of the specified nodes and
+// of the pods running on them into JSON (see NodesSnapshot). Unknown node
+// names are skipped with a warning.
+func (ctc *ContivTelemetryCache) ExportNodesSnapshot(nodeNames []string) ([]byte, error) {
+	snapshot := NodesSnapshot{
+		Nodes: make([]*telemetrymodel.Node, 0, len(nodeNames)),
+		Pods:  make([]*telemetrymodel.Pod, 0),
+	}
+	for _, nodeName := range nodeNames {
+		node, err := ctc.VppCache.RetrieveNodeCopy(nodeName)
+		if err != nil {
+			ctc.Log.Warnf("skipping node %s in snapshot: %s", nodeName, err)
+			continue
+		}
+		snapshot.Nodes = append(snapshot.Nodes, node)
+		snapshot.Pods = append(snapshot.Pods, ctc.K8sCache.RetrievePodsByHostIPAddr(node.ManIPAddr)...)
+	}
+	return json.Marshal(snapshot)
 }
 
 func (ctc *ContivTelemetryCache) nodeEventProcessor() {
@@ -134,6 +593,14 @@ func (ctc *ContivTelemetryCache) nodeEventProcessor() {
 			if !ok {
 				return
 			}
+			if delay := ctc.jitterDelay(); delay > 0 {
+				ctc.jitterTimer.Reset(delay)
+				continue
+			}
+			ctc.Report.Clear()
+			ctc.startNodeInfoCollection()
+
+		case <-ctc.jitterTimer.C:
 			ctc.Report.Clear()
 			ctc.startNodeInfoCollection()
 
@@ -144,6 +611,14 @@ func (ctc *ContivTelemetryCache) nodeEventProcessor() {
 			}
 			ctc.processNodeResponse(data)
 
+		case <-ctc.cycleTimer.C:
+			ctc.Log.Info("Data collection cycle timed out")
+			ctc.processCycleTimeout()
+
+		case req := <-ctc.collectNodesChannel:
+			ctc.Report.Clear()
+			ctc.startNodeSubsetCollection(req)
+
 		case data, ok := <-ctc.dsUpdateChannel:
 			ctc.Log.Info("Received dsUpdate DTO, status: ", ok)
 			if !ok {
@@ -168,12 +643,89 @@ func (ctc *ContivTelemetryCache) startNodeInfoCollection() {
 	}
 
 	ctc.ClearCache()
+	ctc.startCollectionCycle(nodelist)
+}
+
+// startNodeSubsetCollection starts a data collection cycle limited to the
+// nodes named in the request. Unknown node names are skipped with a
+// warning. The data of the other nodes is kept in the cache.
+func (ctc *ContivTelemetryCache) startNodeSubsetCollection(req *collectNodesRequest) {
+	if ctc.validationInProgress {
+		req.result <- fmt.Errorf("data collection/validation already in progress")
+		return
+	}
+
+	nodelist := make([]*telemetrymodel.Node, 0, len(req.nodeNames))
+	nodeNames := make(map[string]bool)
+	for _, nodeName := range req.nodeNames {
+		node, err := ctc.VppCache.RetrieveNode(nodeName)
+		if err != nil {
+			ctc.Report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityWarning,
+				fmt.Sprintf("node %s not found - skipping data collection", nodeName))
+			continue
+		}
+		if !nodeNames[nodeName] {
+			nodeNames[nodeName] = true
+			nodelist = append(nodelist, node)
+		}
+	}
+	if len(nodelist) == 0 {
+		req.result <- fmt.Errorf("none of the nodes %v found", req.nodeNames)
+		return
+	}
+
+	for _, node := range nodelist {
+		ctc.VppCache.ResetNodeData(node.Name)
+	}
+	ctc.cycleNodeNames = nodeNames
+	ctc.cycleResult = req.result
+	ctc.startCollectionCycle(nodelist)
+}
+
+// startCollectionCycle starts the collection of data from the agents on
+// the specified nodes.
+func (ctc *ContivTelemetryCache) startCollectionCycle(nodelist []*telemetrymodel.Node) {
 	ctc.validationInProgress = true
+	ctc.cycleNumNodes = len(nodelist)
+	ctc.cycleTimer.Reset(ctc.CycleTimeout)
+	ctc.cycleCtx, ctc.cycleCancel = context.WithTimeout(context.Background(), ctc.CycleTimeout)
 	for _, node := range nodelist {
 		ctc.collectNodeInfo(node)
 	}
 }
 
+// CollectNodes runs a data collection and validation cycle limited to the
+// specified nodes and waits for it to complete. Only the data of these
+// nodes is re-collected; the data of the other nodes is kept in the cache.
+// Unknown node names are skipped with a warning in the report. An error is
+// returned if none of the nodes is known, if another cycle is in progress
+// or if ctx is done before the cycle completes.
+func (ctc *ContivTelemetryCache) CollectNodes(ctx context.Context, nodeNames []string) error {
+	req := &collectNodesRequest{
+		nodeNames: nodeNames,
+		result:    make(chan error, 1),
+	}
+
+	select {
+	case ctc.collectNodesChannel <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-req.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// inCollectionCycle returns true if data is collected from the node with
+// the specified name in the current data collection cycle.
+func (ctc *ContivTelemetryCache) inCollectionCycle(nodeName string) bool {
+	return ctc.cycleNodeNames == nil || ctc.cycleNodeNames[nodeName]
+}
+
 // collectNodeInfo collects node data from all agents in the Contiv
 // cluster and puts it in the cache
 func (ctc *ContivTelemetryCache) collectNodeInfo(node *telemetrymodel.Node) {
@@ -188,38 +740,54 @@ func (ctc *ContivTelemetryCache) validateNodeInfo() {
 
 	nodelist := ctc.VppCache.RetrieveAllNodes()
 	for _, node := range nodelist {
-		ctc.populateNodeMaps(node)
+		if ctc.inCollectionCycle(node.Name) {
+			ctc.populateNodeMaps(node)
+		}
 	}
 	ctc.Log.Info("Beginning validation of Node Data")
-	ctc.Processor.Validate()
 	ctc.Report.SetTimeStamp(time.Now())
+	ctc.Processor.Validate()
 
 	for _, n := range nodelist {
-		ctc.Report.AppendToNodeReport(n.Name, "Report done.")
+		ctc.Report.Append(n.Name, api.SeverityInfo, "", "Report done.")
 	}
+	ctc.Report.PushSnapshot()
 	ctc.Report.Print()
 	// ctc.ControllerReport.GenerateCRDReport()
 }
 
 //Gathers a number of data points for every node in the Node List
 func (ctc *ContivTelemetryCache) collectAgentInfo(node *telemetrymodel.Node) {
-	client := http.Client{
-		Transport:     nil,
-		CheckRedirect: nil,
-		Jar:           nil,
-		Timeout:       ctc.httpClientTimeout,
+	client := ctc.newAgentClient()
+
+	if ctc.BatchCollect {
+		go ctc.getNodeBatch(ctc.cycleCtx, client, node, ctc.databaseVersion)
+		return
 	}
 
-	go ctc.getNodeInfo(client, node, livenessURL, &telemetrymodel.NodeLiveness{}, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctc.cycleCtx, client, node, ctc.URLPaths.Liveness, &telemetrymodel.NodeLiveness{},
+		ctc.databaseVersion)
+
+	// In LivenessOnlyFirst mode, the rest of the node's data is collected
+	// only after its liveness shows that the node has changed
+	if !ctc.LivenessOnlyFirst {
+		ctc.collectAgentData(ctc.cycleCtx, client, node, ctc.databaseVersion)
+	}
+}
+
+// collectAgentData collects all node data except for liveness from the
+// node's agent.
+func (ctc *ContivTelemetryCache) collectAgentData(ctx context.Context, client AgentClient,
+	node *telemetrymodel.Node, version uint32) {
 
 	nodeInterfaces := make(telemetrymodel.NodeInterfaces, 0)
-	go ctc.getNodeInfo(client, node, interfaceURL, &nodeInterfaces, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.Interfaces, &nodeInterfaces, version)
 
 	nodeBridgeDomains := make(telemetrymodel.NodeBridgeDomains, 0)
-	go ctc.getNodeInfo(client, node, bridgeDomainURL, &nodeBridgeDomains, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.BridgeDomains, &nodeBridgeDomains, version)
 
 	nodel2fibs := make(telemetrymodel.NodeL2FibTable, 0)
-	go ctc.getNodeInfo(client, node, l2FibsURL, &nodel2fibs, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.L2Fibs, &nodel2fibs, version)
 
 	//TODO: Implement getTelemetry correctly.
 	//Does not parse information correctly
@@ -227,47 +795,318 @@ func (ctc *ContivTelemetryCache) collectAgentInfo(node *telemetrymodel.Node) {
 	//go ctc.getNodeInfo(client, node, telemetryURL, &nodetelemetry)
 
 	nodeiparpslice := make(telemetrymodel.NodeIPArpTable, 0)
-	go ctc.getNodeInfo(client, node, arpURL, &nodeiparpslice, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.Arps, &nodeiparpslice, version)
 
 	nodestaticroutes := make(telemetrymodel.NodeStaticRoutes, 0)
-	go ctc.getNodeInfo(client, node, staticRouteURL, &nodestaticroutes, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.StaticRoutes, &nodestaticroutes, version)
 
 	nodeipam := telemetrymodel.IPamEntry{}
-	go ctc.getNodeInfo(client, node, ipamURL, &nodeipam, ctc.databaseVersion)
+	go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.Ipam, &nodeipam, version)
+}
+
+// newAgentClient returns the client used to collect data from agents. If
+// no AgentClient is set, an HTTP client is created. All HTTP clients share
+// the cache's transport, so that connections to an agent are reused across
+// requests and collection cycles.
+func (ctc *ContivTelemetryCache) newAgentClient() AgentClient {
+	if ctc.AgentClient != nil {
+		return ctc.AgentClient
+	}
+	return &httpAgentClient{
+		client: http.Client{
+			Transport:     ctc.transport,
+			CheckRedirect: nil,
+			Jar:           nil,
+			Timeout:       ctc.httpClientTimeout,
+		},
+	}
 }
 
 /* Here are the several functions that run as goroutines to collect information
-about a specific node using an http client. First, an http request is made to the
+about a specific node using an agent client. First, an http request is made to the
 specific url and port of the desired information and the request received is read
 and unmarshalled into a struct to contain that information. Then, a data transfer
 object is created to hold the struct of information as well as the name and is sent
 over the plugins node database channel to node_db_processor.go where it will be read,
 processed, and added to the node database.
 */
-func (ctc *ContivTelemetryCache) getNodeInfo(client http.Client, node *telemetrymodel.Node, url string,
-	nodeInfo interface{}, version uint32) {
+func (ctc *ContivTelemetryCache) getNodeInfo(ctx context.Context, client AgentClient, node *telemetrymodel.Node,
+	url string, nodeInfo interface{}, version uint32) {
+
+	if err := ctc.waitForRequestToken(ctx, node.Name); err != nil {
+		err := fmt.Errorf("getNodeInfo: url: %s rate limiter Error: %s", url, err.Error())
+		ctc.Log.Error(err)
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+		return
+	}
 
-	res, err := client.Get(ctc.getAgentURL(node.ManIPAddr, url))
+	b, statusCode, start, err := ctc.getFromAgent(ctx, client, node, url)
+	if err == io.ErrUnexpectedEOF {
+		err := ctc.truncatedResponseError(node, url, len(b))
+		ctc.notifyResponse(node.Name, url, statusCode, start, err)
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+		return
+	}
 	if err != nil {
 		err := fmt.Errorf("getNodeInfo: url: %s cleintGet Error: %s", url, err.Error())
 		ctc.Log.Error(err)
-		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version}
+		ctc.notifyResponse(node.Name, url, 0, start, err)
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+		return
+	}
+	if statusCode < 200 || statusCode > 299 {
+		err := fmt.Errorf("getNodeInfo: url: %s HTTP res.Status: %d %s", url, statusCode,
+			http.StatusText(statusCode))
+		ctc.Log.Error(err)
+		ctc.notifyResponse(node.Name, url, statusCode, start, err)
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+		return
+	}
+	ctc.notifyResponse(node.Name, url, statusCode, start, nil)
+
+	if err := ctc.incompleteResponseError(node, url, b); err != nil {
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+		return
+	}
+
+	if ctc.ValidateSchemas {
+		if err := validateDTOSchema(nodeInfo, b); err != nil {
+			err := &dtoError{fmt.Sprintf("Invalid data for node %s from url %s: %s", node.Name, url, err)}
+			ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+			return
+		}
+	}
+
+	if err := json.Unmarshal(b, nodeInfo); err != nil {
+		err := &dtoError{fmt.Sprintf("Error unmarshaling data for node %+v: %+v", node.Name, err)}
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
 		return
-	} else if res.StatusCode < 200 || res.StatusCode > 299 {
-		err := fmt.Errorf("getNodeInfo: url: %s HTTP res.Status: %s", url, res.Status)
+	}
+	ctc.nodeResponseChannel <- &NodeDTO{node.Name, nodeInfo, nil, version, url}
+}
+
+// getNodeBatch collects all data about a node in a single request to the
+// agent batch endpoint and splits the response into the individual DTOs.
+// If the agent does not serve the batch endpoint, the data is collected
+// from the individual endpoints.
+func (ctc *ContivTelemetryCache) getNodeBatch(ctx context.Context, client AgentClient, node *telemetrymodel.Node,
+	version uint32) {
+
+	url := ctc.URLPaths.Batch
+	if err := ctc.waitForRequestToken(ctx, node.Name); err != nil {
+		err := fmt.Errorf("getNodeBatch: url: %s rate limiter Error: %s", url, err.Error())
 		ctc.Log.Error(err)
-		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version}
+		ctc.sendBatchErrors(node, version, err)
 		return
 	}
 
-	b, _ := ioutil.ReadAll(res.Body)
-	b = []byte(b)
-	err = json.Unmarshal(b, nodeInfo)
+	b, statusCode, start, err := ctc.getFromAgent(ctx, client, node, url)
+	if err == io.ErrUnexpectedEOF {
+		err := ctc.truncatedResponseError(node, url, len(b))
+		ctc.notifyResponse(node.Name, url, statusCode, start, err)
+		ctc.sendBatchErrors(node, version, err)
+		return
+	}
 	if err != nil {
-		errString := fmt.Sprintf("Error unmarshaling data for node %+v: %+v", node.Name, err)
-		ctc.Report.AppendToNodeReport(node.Name, errString)
+		err := fmt.Errorf("getNodeBatch: url: %s cleintGet Error: %s", url, err.Error())
+		ctc.Log.Error(err)
+		ctc.notifyResponse(node.Name, url, 0, start, err)
+		ctc.sendBatchErrors(node, version, err)
+		return
+	}
+	if statusCode == http.StatusNotFound {
+		ctc.notifyResponse(node.Name, url, statusCode, start, nil)
+		ctc.Log.Infof("Batch endpoint not available on node %s, collecting data from individual endpoints",
+			node.Name)
+		go ctc.getNodeInfo(ctx, client, node, ctc.URLPaths.Liveness, &telemetrymodel.NodeLiveness{}, version)
+		ctc.collectAgentData(ctx, client, node, version)
+		return
+	}
+	if statusCode < 200 || statusCode > 299 {
+		err := fmt.Errorf("getNodeBatch: url: %s HTTP res.Status: %d %s", url, statusCode,
+			http.StatusText(statusCode))
+		ctc.Log.Error(err)
+		ctc.notifyResponse(node.Name, url, statusCode, start, err)
+		ctc.sendBatchErrors(node, version, err)
+		return
+	}
+	ctc.notifyResponse(node.Name, url, statusCode, start, nil)
+
+	if err := ctc.incompleteResponseError(node, url, b); err != nil {
+		ctc.sendBatchErrors(node, version, err)
+		return
+	}
+
+	batch := batchDTO{}
+	if err := json.Unmarshal(b, &batch); err != nil {
+		err := &dtoError{fmt.Sprintf("Error unmarshaling batch data for node %+v: %+v", node.Name, err)}
+		ctc.sendBatchErrors(node, version, err)
+		return
+	}
+
+	dtos := []struct {
+		url      string
+		nodeInfo interface{}
+		present  bool
+	}{
+		{ctc.URLPaths.Liveness, batch.Liveness, batch.Liveness != nil},
+		{ctc.URLPaths.Interfaces, batch.Interfaces, batch.Interfaces != nil},
+		{ctc.URLPaths.BridgeDomains, batch.BridgeDomains, batch.BridgeDomains != nil},
+		{ctc.URLPaths.L2Fibs, batch.L2Fibs, batch.L2Fibs != nil},
+		{ctc.URLPaths.Arps, batch.Arps, batch.Arps != nil},
+		{ctc.URLPaths.StaticRoutes, batch.StaticRoutes, batch.StaticRoutes != nil},
+		{ctc.URLPaths.Ipam, batch.Ipam, batch.Ipam != nil},
+	}
+	for _, dto := range dtos {
+		if !dto.present {
+			err := fmt.Errorf("getNodeBatch: url: %s: data for %s missing in batch payload", url, dto.url)
+			ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, dto.url}
+			continue
+		}
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, dto.nodeInfo, nil, version, dto.url}
+	}
+}
+
+// getFromAgent sends a request for the specified url to the agent on the
+// node. If the agent rejects the request as rate-limited (429), the request
+// is retried after the delay requested by the agent in the Retry-After
+// header, as long as the retry fits into the data collection cycle. The
+// status code and the start time of the last request are returned.
+func (ctc *ContivTelemetryCache) getFromAgent(ctx context.Context, client AgentClient, node *telemetrymodel.Node,
+	url string) ([]byte, int, time.Time, error) {
+
+	for retry := 0; ; retry++ {
+		if ctc.OnRequest != nil {
+			ctc.OnRequest(node.Name, url)
+		}
+		start := time.Now()
+
+		b, statusCode, header, err := client.Get(ctx, ctc.getAgentURL(node, url))
+		if err != nil || statusCode != http.StatusTooManyRequests || retry >= maxRetryAfterTries {
+			return b, statusCode, start, err
+		}
+
+		delay := retryAfterDelay(header.Get("Retry-After"), time.Now(), retryAfterDefault*time.Second)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return b, statusCode, start, err
+		}
+		ctc.notifyResponse(node.Name, url, statusCode, start, fmt.Errorf("rate limited, retry after %s", delay))
+		ctc.Log.Infof("Request %s rate limited by agent on node %s, retrying after %s", url, node.Name, delay)
+
+		select {
+		case <-ctx.Done():
+			return b, statusCode, start, err
+		case <-time.After(delay):
+		}
+
+		if err := ctc.waitForRequestToken(ctx, node.Name); err != nil {
+			return nil, 0, start, err
+		}
+	}
+}
+
+// truncatedResponseError returns the error for a response from the agent
+// on the node whose body was cut off, e.g. because the agent closed the
+// connection mid-stream. received is the number of body bytes received.
+// The error is recorded in the report when the DTO is processed.
+func (ctc *ContivTelemetryCache) truncatedResponseError(node *telemetrymodel.Node, url string,
+	received int) error {
+	err := &dtoError{fmt.Sprintf("truncated response from node %s endpoint %s (received %d bytes)",
+		node.Name, url, received)}
+	ctc.Log.Error(err)
+	return err
+}
+
+// incompleteResponseError returns the error for a successful response from
+// the agent on the node whose body b is empty or a truncated JSON document,
+// or nil if the body is neither. The error is recorded in the report when
+// the DTO is processed.
+func (ctc *ContivTelemetryCache) incompleteResponseError(node *telemetrymodel.Node, url string, b []byte) error {
+	if len(bytes.TrimSpace(b)) == 0 {
+		err := &dtoError{fmt.Sprintf("empty response from node %s endpoint %s", node.Name, url)}
+		ctc.Log.Error(err)
+		return err
+	}
+	if truncatedJSON(b) {
+		return ctc.truncatedResponseError(node, url, len(b))
+	}
+	return nil
+}
+
+// truncatedJSON returns true if b is the beginning of a JSON document that
+// was cut off before its end, as opposed to being malformed.
+func truncatedJSON(b []byte) bool {
+	if json.Valid(b) {
+		return false
+	}
+	var value interface{}
+	err := json.NewDecoder(bytes.NewReader(b)).Decode(&value)
+	return err == io.ErrUnexpectedEOF
+}
+
+// sendBatchErrors reports a failed batch request as a failed DTO for each
+// of the node's DTOs.
+func (ctc *ContivTelemetryCache) sendBatchErrors(node *telemetrymodel.Node, version uint32, err error) {
+	for _, url := range ctc.URLPaths.nodeDTOURLs() {
+		ctc.nodeResponseChannel <- &NodeDTO{node.Name, nil, err, version, url}
+	}
+}
+
+// waitForRequestToken blocks until a request to the agent on the specified
+// node is permitted by the rate limits, or until ctx is done.
+func (ctc *ContivTelemetryCache) waitForRequestToken(ctx context.Context, nodeName string) error {
+	globalLimiter, nodeLimiter := ctc.rateLimiters(nodeName)
+	if nodeLimiter != nil {
+		if err := nodeLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if globalLimiter != nil {
+		if err := globalLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rateLimiters returns the global rate limiter and the rate limiter for
+// the specified node; a limiter is nil if the respective rate is not
+// limited. Limiters are created on first use and their limits are kept in
+// sync with the configured rates.
+func (ctc *ContivTelemetryCache) rateLimiters(nodeName string) (*rate.Limiter, *rate.Limiter) {
+	ctc.limiterMtx.Lock()
+	defer ctc.limiterMtx.Unlock()
+
+	var globalLimiter, nodeLimiter *rate.Limiter
+	if ctc.MaxRequestsPerSecond > 0 {
+		if ctc.rateLimiter == nil {
+			ctc.rateLimiter = rate.NewLimiter(rate.Limit(ctc.MaxRequestsPerSecond), 1)
+		} else {
+			ctc.rateLimiter.SetLimit(rate.Limit(ctc.MaxRequestsPerSecond))
+		}
+		globalLimiter = ctc.rateLimiter
+	}
+	if ctc.MaxNodeRequestsPerSecond > 0 {
+		if ctc.nodeRateLimiters == nil {
+			ctc.nodeRateLimiters = make(map[string]*rate.Limiter)
+		}
+		nodeLimiter = ctc.nodeRateLimiters[nodeName]
+		if nodeLimiter == nil {
+			nodeLimiter = rate.NewLimiter(rate.Limit(ctc.MaxNodeRequestsPerSecond), 1)
+			ctc.nodeRateLimiters[nodeName] = nodeLimiter
+		} else {
+			nodeLimiter.SetLimit(rate.Limit(ctc.MaxNodeRequestsPerSecond))
+		}
+	}
+	return globalLimiter, nodeLimiter
+}
+
+// notifyResponse invokes the OnResponse hook, if set, for a completed
+// agent request that was started at 'start'.
+func (ctc *ContivTelemetryCache) notifyResponse(nodeName, url string, statusCode int, start time.Time, err error) {
+	if ctc.OnResponse != nil {
+		ctc.OnResponse(nodeName, url, statusCode, time.Since(start), err)
 	}
-	ctc.nodeResponseChannel <- &NodeDTO{node.Name, nodeInfo, err, version}
 }
 
 // populateNodeMaps populates many of needed node maps for processing once
@@ -310,8 +1149,11 @@ func (ctc *ContivTelemetryCache) populateNodeMaps(node *telemetrymodel.Node) {
 }
 
 // getAgentURL creates the URL for the data we're trying to retrieve
-func (ctc *ContivTelemetryCache) getAgentURL(ipAddr string, url string) string {
-	return "http://" + ipAddr + ctc.agentPort + url
+func (ctc *ContivTelemetryCache) getAgentURL(node *telemetrymodel.Node, url string) string {
+	if ctc.NodeURLResolver != nil {
+		return strings.TrimSuffix(ctc.NodeURLResolver(node), "/") + url
+	}
+	return "http://" + node.ManIPAddr + ctc.agentPort + url
 }
 
 // waitForValidationToFinish waits until the node cache has been cleared at
@@ -331,25 +1173,213 @@ func (ctc *ContivTelemetryCache) waitForValidationToFinish() int {
 // node has enough DTOs to fully process information. It then clears the
 // node DTO map after it is finished with it.
 func (ctc *ContivTelemetryCache) processNodeResponse(data *NodeDTO) {
-	nodelist := ctc.VppCache.RetrieveAllNodes()
 	if data.version >= ctc.databaseVersion {
-		ctc.dtoList = append(ctc.dtoList, data)
+		ctc.addNodeDTO(data)
+		if ctc.LivenessOnlyFirst && !ctc.BatchCollect && data.url == ctc.URLPaths.Liveness {
+			ctc.processNodeLiveness(data)
+		}
+	}
+	if len(ctc.dtoList) == numDTOs*ctc.cycleNumNodes {
+		ctc.stopCycleTimer()
+		ctc.finishCollectionCycle()
+	}
+}
+
+// addNodeDTO adds a DTO to the list of DTOs collected in the current cycle.
+func (ctc *ContivTelemetryCache) addNodeDTO(data *NodeDTO) {
+	ctc.dtoList = append(ctc.dtoList, data)
+	if data.err == nil {
+		if ctc.dtoPresence[data.NodeName] == nil {
+			ctc.dtoPresence[data.NodeName] = make(map[string]bool)
+		}
+		ctc.dtoPresence[data.NodeName][data.url] = true
+	}
+}
+
+// processNodeLiveness is used in the LivenessOnlyFirst mode to decide
+// whether the rest of the node's data must be collected. If the node's
+// LastUpdate time has not advanced since the previous cycle, the node's
+// data from the previous cycle is reused; otherwise, the data is collected
+// from the node's agent.
+func (ctc *ContivTelemetryCache) processNodeLiveness(data *NodeDTO) {
+	prevDTOs := ctc.prevNodeDTOs[data.NodeName]
+	if data.err == nil && len(prevDTOs) == numDTOs {
+		prevLiveness := prevDTOs[0].NodeInfo.(*telemetrymodel.NodeLiveness)
+		liveness := data.NodeInfo.(*telemetrymodel.NodeLiveness)
+		if liveness.LastUpdate == prevLiveness.LastUpdate {
+			ctc.Log.Infof("Node %s unchanged, reusing data from previous cycle", data.NodeName)
+			for _, dto := range prevDTOs[1:] {
+				ctc.addNodeDTO(dto)
+			}
+			return
+		}
 	}
-	if len(ctc.dtoList) == numDTOs*len(nodelist) {
-		ctc.setNodeData()
-		ctc.validateNodeInfo()
-		ctc.dtoList = ctc.dtoList[0:0]
-		ctc.validationInProgress = false
+
+	node, err := ctc.VppCache.RetrieveNode(data.NodeName)
+	if err != nil {
+		// The node's remaining DTOs are reported as failed, so that the
+		// cycle does not wait for them until CycleTimeout. They are added
+		// directly, since this runs on the goroutine that reads the
+		// response channel.
+		err = fmt.Errorf("failed to collect data for node %s: %s", data.NodeName, err)
+		ctc.Log.Error(err)
+		for _, url := range ctc.URLPaths.nodeDTOURLs()[1:] {
+			ctc.addNodeDTO(&NodeDTO{data.NodeName, nil, err, data.version, url})
+		}
+		return
+	}
+	ctc.collectAgentData(ctc.cycleCtx, ctc.newAgentClient(), node, ctc.databaseVersion)
+}
+
+// saveNodeDTOs stores the DTOs collected in the current cycle, so that
+// they can be reused for unchanged nodes in the LivenessOnlyFirst mode.
+// The liveness DTO is stored first in each node's DTO list. Nodes whose
+// liveness DTO was not received successfully are not stored.
+func (ctc *ContivTelemetryCache) saveNodeDTOs() {
+	ctc.prevNodeDTOs = make(map[string][]*NodeDTO)
+	for _, data := range ctc.dtoList {
+		if data.url == ctc.URLPaths.Liveness && data.err == nil {
+			ctc.prevNodeDTOs[data.NodeName] = append([]*NodeDTO{data}, ctc.prevNodeDTOs[data.NodeName]...)
+		}
+	}
+	for _, data := range ctc.dtoList {
+		if data.url != ctc.URLPaths.Liveness && ctc.prevNodeDTOs[data.NodeName] != nil {
+			ctc.prevNodeDTOs[data.NodeName] = append(ctc.prevNodeDTOs[data.NodeName], data)
+		}
+	}
+}
+
+// processCycleTimeout is invoked when the data collection cycle does not
+// complete within CycleTimeout. DTOs that have not been received from
+// agents are reported as failed, outstanding DTOs are invalidated and
+// validation proceeds with the data collected so far.
+func (ctc *ContivTelemetryCache) processCycleTimeout() {
+	if !ctc.validationInProgress {
+		return
+	}
+
+	received := make(map[string]map[string]bool)
+	for _, data := range ctc.dtoList {
+		if received[data.NodeName] == nil {
+			received[data.NodeName] = make(map[string]bool)
+		}
+		received[data.NodeName][data.url] = true
+	}
+
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		if !ctc.inCollectionCycle(node.Name) {
+			continue
+		}
+		for _, url := range ctc.URLPaths.nodeDTOURLs() {
+			if !received[node.Name][url] {
+				errString := fmt.Sprintf("collection incomplete: no response for url %s "+
+					"within cycle timeout %s", url, ctc.CycleTimeout)
+				ctc.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			}
+		}
+	}
+
+	// Discard DTOs that are still outstanding from this cycle
+	ctc.databaseVersion++
+	ctc.finishCollectionCycle()
+}
+
+// finishCollectionCycle stores the DTOs collected in the current cycle into
+// the cache, validates the data and readies the cache for the next cycle.
+// The cache is Synced when its first full-cluster cycle finishes, whether
+// all DTOs were received or the cycle timed out.
+func (ctc *ContivTelemetryCache) finishCollectionCycle() {
+	fullCycle := ctc.cycleNodeNames == nil
+	ctc.ValidateCollectionCompleteness()
+	if ctc.LivenessOnlyFirst {
+		ctc.saveNodeDTOs()
+	}
+	ctc.setNodeData(ctc.dtoList)
+	ctc.validateNodeInfo()
+	ctc.dtoList = ctc.dtoList[0:0]
+	ctc.dtoPresence = make(map[string]map[string]bool)
+	if ctc.cycleCancel != nil {
+		ctc.cycleCancel()
+	}
+	ctc.validationInProgress = false
+	ctc.cycleNodeNames = nil
+	if ctc.cycleResult != nil {
+		ctc.cycleResult <- nil
+		ctc.cycleResult = nil
+	}
+	if fullCycle {
+		ctc.markSynced()
+	}
+}
+
+// ValidateCollectionCompleteness reports, for each node in the current data
+// collection cycle, every DTO required for validation (liveness,
+// interfaces, BDs, L2FIBs and ARPs) that was not successfully received from
+// the node's agent in the cycle.
+func (ctc *ContivTelemetryCache) ValidateCollectionCompleteness() {
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		if !ctc.inCollectionCycle(node.Name) {
+			continue
+		}
+		for _, dto := range ctc.URLPaths.requiredDTOs() {
+			if !ctc.dtoPresence[node.Name][dto.url] {
+				errString := fmt.Sprintf("incomplete data: %s missing %s", node.Name, dto.name)
+				ctc.Report.AppendToNodeReport(node.Name, errString)
+			}
+		}
+	}
+}
+
+// jitterDelay returns a random delay for the start of the next data
+// collection cycle, up to PollJitter times the collection interval.
+func (ctc *ContivTelemetryCache) jitterDelay() time.Duration {
+	jitter := ctc.PollJitter
+	if jitter <= 0 {
+		return 0
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	return time.Duration(ctc.jitterRand.Float64() * jitter * float64(ctc.collectionInterval))
+}
+
+// stopCycleTimer stops the data collection cycle timer and drains its
+// channel if the timer has already fired.
+func (ctc *ContivTelemetryCache) stopCycleTimer() {
+	if !ctc.cycleTimer.Stop() {
+		select {
+		case <-ctc.cycleTimer.C:
+		default:
+		}
 	}
 }
 
 // setNodeData will iterate through the dtoList, read the type of dto, and
 // assign the dto info to the name associated with the DTO.
-func (ctc *ContivTelemetryCache) setNodeData() {
-	for _, data := range ctc.dtoList {
+func (ctc *ContivTelemetryCache) setNodeData(dtoList []*NodeDTO) {
+	collectionErrors := make(map[string]int)
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		collectionErrors[node.Name] = 0
+	}
+	defer func() {
+		ctc.collectionErrMtx.Lock()
+		ctc.collectionErrors = collectionErrors
+		ctc.collectionErrMtx.Unlock()
+	}()
+
+	reportedErrs := make(map[*dtoError]bool)
+	for _, data := range dtoList {
 		err := error(nil)
 
 		if data.err != nil {
+			collectionErrors[data.NodeName]++
+			if dtoErr, ok := data.err.(*dtoError); ok {
+				if !reportedErrs[dtoErr] {
+					reportedErrs[dtoErr] = true
+					ctc.Report.LogErrAndAppendToNodeReport(data.NodeName, dtoErr.Error())
+				}
+				continue
+			}
 			err = fmt.Errorf("node %+v has nodeDTO %+v and http error %s", data.NodeName, data, data.err)
 			ctc.Report.LogErrAndAppendToNodeReport(data.NodeName, err.Error())
 			continue
@@ -409,3 +1439,49 @@ func (ctc *ContivTelemetryCache) processDataStoreUpdate(data interface{}) {
 		ctc.Log.Errorf("unknown type received, %s", reflect.TypeOf(data))
 	}
 }
+
+// setDefaults sets all empty paths to their default values.
+func (p *URLPaths) setDefaults() {
+	if p.Liveness == "" {
+		p.Liveness = livenessURL
+	}
+	if p.Interfaces == "" {
+		p.Interfaces = interfaceURL
+	}
+	if p.BridgeDomains == "" {
+		p.BridgeDomains = bridgeDomainURL
+	}
+	if p.L2Fibs == "" {
+		p.L2Fibs = l2FibsURL
+	}
+	if p.Arps == "" {
+		p.Arps = arpURL
+	}
+	if p.StaticRoutes == "" {
+		p.StaticRoutes = staticRouteURL
+	}
+	if p.Ipam == "" {
+		p.Ipam = ipamURL
+	}
+	if p.Batch == "" {
+		p.Batch = batchURL
+	}
+}
+
+// requiredDTOs lists the DTOs that must be received from each node's agent
+// for the node's data to be validated.
+func (p *URLPaths) requiredDTOs() []dtoPath {
+	return []dtoPath{
+		{"liveness", p.Liveness},
+		{"interfaces", p.Interfaces},
+		{"BDs", p.BridgeDomains},
+		{"L2FIBs", p.L2Fibs},
+		{"ARPs", p.Arps},
+	}
+}
+
+// nodeDTOURLs lists the agent URLs from which a DTO is expected for each
+// node in every data collection cycle.
+func (p *URLPaths) nodeDTOURLs() []string {
+	return []string{p.Liveness, p.Interfaces, p.BridgeDomains, p.L2Fibs, p.Arps, p.StaticRoutes, p.Ipam}
+}
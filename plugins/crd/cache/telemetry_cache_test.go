@@ -17,23 +17,41 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"github.com/contiv/vpp/plugins/crd/api"
 	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
 	"github.com/contiv/vpp/plugins/crd/datastore"
 	"github.com/contiv/vpp/plugins/crd/testdata"
+	nodemodel "github.com/contiv/vpp/plugins/ksr/model/node"
 	"github.com/ligato/cn-infra/logging"
 	"github.com/ligato/cn-infra/logging/logrus"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
 	"github.com/onsi/gomega"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
 const (
-	noError        = iota
-	inject404Error = iota
-	injectDelay    = iota
-	testAgentPort  = ":8080"
+	noError         = iota
+	inject404Error  = iota
+	injectDelay     = iota
+	inject404L2Fib  = iota
+	inject404Batch  = iota
+	inject429Once   = iota
+	injectTruncated = iota
+	testAgentPort   = ":8080"
+
+	customInterfaceURL = "/vpp/dump/v2/interfaces"
+
+	// unreachablePrefix prefixes the URL paths of an agent that never
+	// responds within the cycle timeout
+	unreachablePrefix = "/unreachable"
 )
 
 type cacheTestVars struct {
@@ -45,12 +63,18 @@ type cacheTestVars struct {
 	telemetryCache *ContivTelemetryCache
 	tickerChan     chan time.Time
 
+	// throttled is set when a liveness request was answered with 429
+	throttleMtx sync.Mutex
+	throttled   bool
+
 	// Mock data
 	nodeLiveness      *telemetrymodel.NodeLiveness
 	nodeInterfaces    map[int]telemetrymodel.NodeInterface
 	nodeBridgeDomains map[int]telemetrymodel.NodeBridgeDomain
 	nodeL2Fibs        map[string]telemetrymodel.NodeL2FibEntry
 	nodeIPArps        []telemetrymodel.NodeIPArpEntry
+	nodeStaticRoutes  []telemetrymodel.NodeIPRoute
+	nodeIPam          *telemetrymodel.IPamEntry
 
 	report *datastore.SimpleReport
 }
@@ -60,10 +84,15 @@ var ctv cacheTestVars
 func (ptv *cacheTestVars) startMockHTTPServer() {
 	ptv.srv = &http.Server{Addr: testAgentPort}
 
+	// Listen before the tests start, so that the first request does not
+	// race with the server start-up
+	listener, err := net.Listen("tcp", testAgentPort)
+	gomega.Expect(err).To(gomega.BeNil())
+
 	go func() {
-		if err := ptv.srv.ListenAndServe(); err != nil {
+		if err := ptv.srv.Serve(listener); err != nil {
 			// cannot panic, because this probably is an intentional close
-			ptv.log.Error("Httpserver: ListenAndServe() error: %s", err)
+			ptv.log.Errorf("Httpserver: Serve() error: %s", err)
 			gomega.Expect(err).To(gomega.BeNil())
 		}
 	}()
@@ -78,7 +107,47 @@ func registerHTTPHandlers() {
 			return
 		}
 
-		if ctv.injectError == injectDelay {
+		if ctv.injectError == inject404L2Fib && r.URL.Path == l2FibsURL {
+			w.WriteHeader(404)
+			w.Write([]byte("page not found - invalid path: " + r.URL.Path))
+			return
+		}
+
+		if ctv.injectError == inject404Batch && r.URL.Path == batchURL {
+			w.WriteHeader(404)
+			w.Write([]byte("page not found - invalid path: " + r.URL.Path))
+			return
+		}
+
+		if ctv.injectError == inject429Once && r.URL.Path == livenessURL {
+			ctv.throttleMtx.Lock()
+			throttle := !ctv.throttled
+			ctv.throttled = true
+			ctv.throttleMtx.Unlock()
+			if throttle {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		if ctv.injectError == injectTruncated && r.URL.Path == livenessURL {
+			writeTruncatedResponse(w, `{"build_version": "v1.2`, true)
+			return
+		}
+
+		if ctv.injectError == injectTruncated && r.URL.Path == interfaceURL {
+			writeTruncatedResponse(w, `{"1": {"interface": {"name": "GigabitEthernet0/8/0"`, false)
+			return
+		}
+
+		if ctv.injectError == injectTruncated && r.URL.Path == l2FibsURL {
+			// An empty, but complete response
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if ctv.injectError == injectDelay || strings.HasPrefix(r.URL.Path, unreachablePrefix) {
 			time.Sleep(3 * time.Second)
 		}
 
@@ -87,7 +156,7 @@ func registerHTTPHandlers() {
 		switch r.URL.Path {
 		case livenessURL:
 			data = ctv.nodeLiveness
-		case interfaceURL:
+		case interfaceURL, customInterfaceURL:
 			data = ctv.nodeInterfaces
 		case l2FibsURL:
 			data = ctv.nodeL2Fibs
@@ -95,6 +164,18 @@ func registerHTTPHandlers() {
 			data = ctv.nodeBridgeDomains
 		case arpURL:
 			data = ctv.nodeIPArps
+		case staticRouteURL:
+			data = ctv.nodeStaticRoutes
+		case ipamURL:
+			data = ctv.nodeIPam
+		case batchURL:
+			data = map[string]interface{}{
+				"liveness":       ctv.nodeLiveness,
+				"interfaces":     ctv.nodeInterfaces,
+				"bridge_domains": ctv.nodeBridgeDomains,
+				"l2_fibs":        ctv.nodeL2Fibs,
+				"arps":           ctv.nodeIPArps,
+			}
 		default:
 			ctv.log.Error("unknown URL: ", r.URL)
 			w.WriteHeader(404)
@@ -116,6 +197,36 @@ func registerHTTPHandlers() {
 
 }
 
+// mockAgentClient is an AgentClient that returns canned payloads keyed by
+// URL path. Requests to failHost are answered with 404.
+type mockAgentClient struct {
+	mtx       sync.Mutex
+	responses map[string]interface{}
+	requested []string
+	failHost  string
+}
+
+func (c *mockAgentClient) Get(ctx context.Context, agentURL string) ([]byte, int, http.Header, error) {
+	u, err := url.Parse(agentURL)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	c.mtx.Lock()
+	c.requested = append(c.requested, u.Path)
+	c.mtx.Unlock()
+
+	data, ok := c.responses[u.Path]
+	if !ok || u.Hostname() == c.failHost {
+		return []byte("page not found - invalid path: " + u.Path), http.StatusNotFound, nil, nil
+	}
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, http.StatusInternalServerError, nil, nil
+	}
+	return buf, http.StatusOK, nil, nil
+}
+
 func (ptv *cacheTestVars) shutdownMockHTTPServer() {
 	if err := ptv.srv.Shutdown(context.TODO()); err != nil {
 		panic(err)
@@ -140,7 +251,7 @@ func TestTelemetryCache(t *testing.T) {
 	// Initialize mock-ticker channel
 	ctv.tickerChan = make(chan time.Time)
 	// Initialize report
-	ctv.report = datastore.NewSimpleReport(ctv.log)
+	ctv.report = datastore.NewSimpleReport(ctv.log, 0)
 	// Suppress printing of output report to screen during testing
 	ctv.report.Output = &nullWriter{}
 
@@ -167,12 +278,14 @@ func TestTelemetryCache(t *testing.T) {
 		Report:   ctv.report,
 	}
 	ctv.telemetryCache.Processor = &mockProcessor{}
-	ctv.telemetryCache.Init()
+	ctv.telemetryCache.init()
 
-	// Override default telemetryCache behavior
+	// Override default telemetryCache behavior before the telemetryCache
+	// is started, so that it never waits on the default ticker
 	ctv.telemetryCache.ticker.Stop() // Do not periodically poll agents
 	ctv.telemetryCache.ticker = newMockTicker()
 	ctv.telemetryCache.agentPort = testAgentPort // Override agentPort
+	go ctv.telemetryCache.nodeEventProcessor()
 
 	// Init & populate the test data
 	testdata.CreateNodeTestData(ctv.telemetryCache.VppCache)
@@ -185,18 +298,40 @@ func TestTelemetryCache(t *testing.T) {
 	ctv.nodeIPArps = node.NodeIPArp
 	ctv.nodeL2Fibs = node.NodeL2Fibs
 	ctv.nodeLiveness = node.NodeLiveness
+	ctv.nodeStaticRoutes = node.NodeStaticRoutes
+	ctv.nodeIPam = node.NodeIPam
 
 	// Do the testing
 	t.Run("collectAgentInfoNoError", testCollectAgentInfoNoError)
+	t.Run("collectAgentInfoHealthyCycle", testCollectAgentInfoHealthyCycle)
 	t.Run("collectAgentInfoWithHTTPError", testCollectAgentInfoWithHTTPError)
 	t.Run("collectAgentInfoWithTimeout", testCollectAgentInfoWithTimeout)
 	t.Run("collectAgentInfoValidationInProgress", testCollectAgentInfoValidationInProgress)
+	t.Run("collectAgentInfoWithCycleTimeout", testCollectAgentInfoWithCycleTimeout)
+	t.Run("syncWithUnreachableNode", testSyncWithUnreachableNode)
+	t.Run("collectAgentInfoWithHooks", testCollectAgentInfoWithHooks)
+	t.Run("collectAgentInfoWithCustomURLPaths", testCollectAgentInfoWithCustomURLPaths)
+	t.Run("collectAgentInfoIncomplete", testCollectAgentInfoIncomplete)
+	t.Run("pollJitter", testPollJitter)
+	t.Run("collectAgentInfoLivenessOnlyFirst", testCollectAgentInfoLivenessOnlyFirst)
+	t.Run("collectAgentInfoNodeDeleted", testCollectAgentInfoNodeDeleted)
+	t.Run("collectAgentInfoConnectionReuse", testCollectAgentInfoConnectionReuse)
+	t.Run("collectAgentInfoWithAgentClient", testCollectAgentInfoWithAgentClient)
+	t.Run("collectAgentInfoWithRateLimit", testCollectAgentInfoWithRateLimit)
+	t.Run("collectAgentInfoBatch", testCollectAgentInfoBatch)
+	t.Run("collectionErrorCounts", testCollectionErrorCounts)
+	t.Run("collectAgentInfoRetryAfter", testCollectAgentInfoRetryAfter)
+	t.Run("collectNodes", testCollectNodes)
+	t.Run("collectAgentInfoSchemaViolation", testCollectAgentInfoSchemaViolation)
+	t.Run("collectAgentInfoNodeURLResolver", testCollectAgentInfoNodeURLResolver)
+	t.Run("collectAgentInfoTruncated", testCollectAgentInfoTruncated)
 
 	// Shutdown the mock HTTP server
 	// ctv.shutdownMockHTTPServer()
 }
 
 func testCollectAgentInfoNoError(t *testing.T) {
+	ctv.telemetryCache.ReinitializeCache()
 	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
 
 	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
@@ -213,6 +348,32 @@ func testCollectAgentInfoNoError(t *testing.T) {
 	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeEquivalentTo(ctv.nodeBridgeDomains))
 	gomega.Expect(node.NodeL2Fibs).To(gomega.BeEquivalentTo(ctv.nodeL2Fibs))
 	gomega.Expect(node.NodeIPArp).To(gomega.BeEquivalentTo(ctv.nodeIPArps))
+
+	// The cache is synced after the first full data collection cycle
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	gomega.Expect(ctv.telemetryCache.WaitForSync(ctx)).To(gomega.Succeed())
+}
+
+func testCollectAgentInfoHealthyCycle(t *testing.T) {
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	err := ctv.telemetryCache.K8sCache.CreateK8sNode("k8s-master", "10.1.1.0/24", "", []*nodemodel.NodeAddress{
+		{Type: nodemodel.NodeAddress_NodeInternalIP, Address: "localhost"},
+		{Type: nodemodel.NodeAddress_NodeHostName, Address: "k8s-master"},
+	}, &nodemodel.NodeSystemInfo{})
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	// The end of the report is recorded for each node, but a healthy cycle
+	// records no errors
+	gomega.Expect(ctv.report.Data["k8s-master"]).To(gomega.Equal([]string{"Report done."}))
+	gomega.Expect(ctv.report.CountBySeverity()[api.SeverityError]).To(gomega.Equal(0))
 }
 
 func testCollectAgentInfoWithHTTPError(t *testing.T) {
@@ -231,7 +392,7 @@ func testCollectAgentInfoWithHTTPError(t *testing.T) {
 	time.Sleep(1 * time.Millisecond)
 	ctv.telemetryCache.waitForValidationToFinish()
 
-	gomega.Expect(grep(ctv.report.Data["k8s-master"], "404 Not Found")).To(gomega.Equal(numDTOs))
+	expectNodeEntries("404 Not Found", "k8s-master", numDTOs)
 }
 
 func testCollectAgentInfoWithTimeout(t *testing.T) {
@@ -251,8 +412,7 @@ func testCollectAgentInfoWithTimeout(t *testing.T) {
 	time.Sleep(1 * time.Millisecond)
 	ctv.telemetryCache.waitForValidationToFinish()
 
-	gomega.Expect(grep(ctv.report.Data["k8s-master"], "Timeout exceeded")).
-		To(gomega.Equal(numDTOs))
+	expectNodeEntries("Timeout exceeded", "k8s-master", numDTOs)
 }
 
 func testCollectAgentInfoValidationInProgress(t *testing.T) {
@@ -269,6 +429,1051 @@ func testCollectAgentInfoValidationInProgress(t *testing.T) {
 	gomega.Expect(grep(ctv.logWriter.log, "Skipping data collection")).To(gomega.Equal(1))
 }
 
+func testCollectAgentInfoWithCycleTimeout(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.CycleTimeout = 100 * time.Millisecond
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	_, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	ctv.injectError = injectDelay
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete at the cycle deadline
+	start := time.Now()
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	gomega.Expect(time.Since(start)).To(gomega.BeNumerically("<", 1*time.Second))
+	expectNodeEntries("collection incomplete", "k8s-master", numDTOs)
+
+	ctv.telemetryCache.CycleTimeout = cycleTimeout * time.Second
+	ctv.injectError = noError
+}
+
+func testSyncWithUnreachableNode(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+
+	// Start from a cache that has not been synced yet
+	ctv.telemetryCache.syncMtx.Lock()
+	ctv.telemetryCache.Synced = false
+	ctv.telemetryCache.syncedCh = nil
+	ctv.telemetryCache.syncMtx.Unlock()
+
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.CycleTimeout = 100 * time.Millisecond
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.telemetryCache.VppCache.CreateNode(2, "k8s-worker1", "10.20.0.10", "localhost")
+
+	// The agent on k8s-worker1 does not respond within the cycle timeout
+	ctv.telemetryCache.NodeURLResolver = func(node *telemetrymodel.Node) string {
+		if node.Name == "k8s-worker1" {
+			return "http://localhost" + testAgentPort + unreachablePrefix + "/"
+		}
+		return "http://localhost" + testAgentPort + "/"
+	}
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete at the cycle deadline
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	expectNodeEntries("collection incomplete", "k8s-worker1", numDTOs)
+
+	// The first full cycle syncs the cache even though it timed out
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	gomega.Expect(ctv.telemetryCache.WaitForSync(ctx)).To(gomega.Succeed())
+
+	ctv.telemetryCache.NodeURLResolver = nil
+	ctv.telemetryCache.CycleTimeout = cycleTimeout * time.Second
+}
+
+// hookRecord holds the arguments of a single OnRequest / OnResponse
+// hook invocation.
+type hookRecord struct {
+	nodeName   string
+	url        string
+	statusCode int
+	err        error
+}
+
+func testCollectAgentInfoWithHooks(t *testing.T) {
+	var mtx sync.Mutex
+	requests := make(map[string]hookRecord)
+	responses := make(map[string]hookRecord)
+
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requests[url] = hookRecord{nodeName: nodeName, url: url}
+	}
+	ctv.telemetryCache.OnResponse = func(nodeName, url string, statusCode int, latency time.Duration, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		responses[url] = hookRecord{nodeName: nodeName, url: url, statusCode: statusCode, err: err}
+	}
+
+	for _, injectError := range []int{noError, inject404Error} {
+		ctv.logWriter.clearLog()
+		ctv.telemetryCache.ReinitializeCache()
+		ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+		ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+		ctv.injectError = injectError
+
+		// Kick the telemetryCache to collect & validate data, give it an opportunity
+		// to run and wait for it to complete
+		ctv.tickerChan <- time.Time{}
+		time.Sleep(1 * time.Millisecond)
+		ctv.telemetryCache.waitForValidationToFinish()
+
+		mtx.Lock()
+		gomega.Expect(len(requests)).To(gomega.Equal(numDTOs))
+		gomega.Expect(len(responses)).To(gomega.Equal(numDTOs))
+		for url, r := range responses {
+			gomega.Expect(requests[url].nodeName).To(gomega.Equal("k8s-master"))
+			gomega.Expect(r.nodeName).To(gomega.Equal("k8s-master"))
+		}
+		if injectError == noError {
+			gomega.Expect(responses[livenessURL].statusCode).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(responses[livenessURL].err).To(gomega.BeNil())
+		} else {
+			gomega.Expect(responses[livenessURL].statusCode).To(gomega.Equal(http.StatusNotFound))
+			gomega.Expect(responses[livenessURL].err).To(gomega.Not(gomega.BeNil()))
+		}
+		mtx.Unlock()
+	}
+
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.OnResponse = nil
+	ctv.injectError = noError
+}
+
+func testCollectAgentInfoWithCustomURLPaths(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.URLPaths.Interfaces = customInterfaceURL
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	var mtx sync.Mutex
+	requested := make(map[string]bool)
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requested[url] = true
+	}
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	mtx.Lock()
+	gomega.Expect(requested[customInterfaceURL]).To(gomega.BeTrue())
+	gomega.Expect(requested[interfaceURL]).To(gomega.BeFalse())
+	mtx.Unlock()
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.URLPaths.Interfaces = interfaceURL
+}
+
+func testCollectAgentInfoIncomplete(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	_, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	ctv.injectError = inject404L2Fib
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	missing := ctv.report.FilterReport("incomplete data")
+	gomega.Expect(missing).To(gomega.HaveLen(1))
+	gomega.Expect(missing[0].NodeName).To(gomega.Equal("k8s-master"))
+	gomega.Expect(missing[0].Message).To(gomega.Equal("incomplete data: k8s-master missing L2FIBs"))
+
+	ctv.injectError = noError
+}
+
+func testPollJitter(t *testing.T) {
+	ctc := &ContivTelemetryCache{collectionInterval: collectionInterval * time.Minute}
+
+	// Jitter is disabled by default
+	gomega.Expect(ctc.jitterDelay()).To(gomega.BeZero())
+
+	ctc.PollJitter = 0.25
+	ctc.jitterRand = rand.New(rand.NewSource(1))
+	delays := make([]time.Duration, 0)
+	for i := 0; i < 100; i++ {
+		delay := ctc.jitterDelay()
+		gomega.Expect(delay).To(gomega.BeNumerically(">=", 0))
+		gomega.Expect(delay).To(gomega.BeNumerically("<", 15*time.Second))
+		delays = append(delays, delay)
+	}
+
+	// The same seed produces the same delays
+	ctc.jitterRand = rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		gomega.Expect(ctc.jitterDelay()).To(gomega.Equal(delays[i]))
+	}
+}
+
+func testCollectAgentInfoLivenessOnlyFirst(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.LivenessOnlyFirst = true
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	var mtx sync.Mutex
+	requested := make(map[string]int)
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requested[url]++
+	}
+	runCycle := func() {
+		mtx.Lock()
+		requested = make(map[string]int)
+		mtx.Unlock()
+
+		// Kick the telemetryCache to collect & validate data, give it an opportunity
+		// to run and wait for it to complete
+		ctv.tickerChan <- time.Time{}
+		time.Sleep(1 * time.Millisecond)
+		ctv.telemetryCache.waitForValidationToFinish()
+	}
+
+	// First cycle: all data is collected
+	runCycle()
+	mtx.Lock()
+	gomega.Expect(requested[livenessURL]).To(gomega.Equal(1))
+	gomega.Expect(requested[interfaceURL]).To(gomega.Equal(1))
+	mtx.Unlock()
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	// Second cycle: liveness is unchanged, data from the first cycle is reused
+	runCycle()
+	mtx.Lock()
+	gomega.Expect(requested[livenessURL]).To(gomega.Equal(1))
+	gomega.Expect(requested[interfaceURL]).To(gomega.Equal(0))
+	mtx.Unlock()
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeEquivalentTo(ctv.nodeL2Fibs))
+
+	// Third cycle: liveness has advanced, all data is collected again
+	prevLiveness := *ctv.nodeLiveness
+	ctv.nodeLiveness.LastUpdate++
+	runCycle()
+	mtx.Lock()
+	gomega.Expect(requested[livenessURL]).To(gomega.Equal(1))
+	gomega.Expect(requested[interfaceURL]).To(gomega.Equal(1))
+	mtx.Unlock()
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	*ctv.nodeLiveness = prevLiveness
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.LivenessOnlyFirst = false
+}
+
+func testCollectAgentInfoNodeDeleted(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.LivenessOnlyFirst = true
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.telemetryCache.VppCache.CreateNode(2, "k8s-worker1", "10.20.0.10", "localhost")
+
+	// k8s-worker1 is deleted while its liveness is being collected
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		if nodeName == "k8s-worker1" && url == livenessURL {
+			ctv.telemetryCache.VppCache.DeleteNode(nodeName)
+		}
+	}
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	start := time.Now()
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	// The cycle completes without waiting for the cycle timeout
+	gomega.Expect(time.Since(start)).To(gomega.BeNumerically("<", ctv.telemetryCache.CycleTimeout))
+	gomega.Expect(ctv.report.FilterReport("collection incomplete")).To(gomega.BeEmpty())
+	// The data of the deleted node that was not collected is reported as failed
+	expectNodeEntries("failed to collect data for node k8s-worker1", "k8s-worker1", numDTOs-1)
+
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.LivenessOnlyFirst = false
+}
+
+func testCollectAgentInfoConnectionReuse(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	// Count the connections opened to the agent
+	var mtx sync.Mutex
+	dials := 0
+	requests := 0
+	transport := ctv.telemetryCache.transport
+	transport.CloseIdleConnections()
+	dialContext := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		mtx.Lock()
+		dials++
+		mtx.Unlock()
+		return dialContext(ctx, network, addr)
+	}
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requests++
+	}
+
+	numCycles := 3
+	for i := 0; i < numCycles; i++ {
+		// Kick the telemetryCache to collect & validate data, give it an opportunity
+		// to run and wait for it to complete
+		ctv.tickerChan <- time.Time{}
+		time.Sleep(1 * time.Millisecond)
+		ctv.telemetryCache.waitForValidationToFinish()
+	}
+
+	// At most one connection per concurrent request is ever opened; all
+	// subsequent requests reuse idle connections
+	mtx.Lock()
+	gomega.Expect(requests).To(gomega.Equal(numCycles * numDTOs))
+	gomega.Expect(dials).To(gomega.BeNumerically(">", 0))
+	gomega.Expect(dials).To(gomega.BeNumerically("<=", numDTOs))
+	mtx.Unlock()
+
+	transport.DialContext = dialContext
+	ctv.telemetryCache.OnRequest = nil
+}
+
+func testCollectAgentInfoWithAgentClient(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	// The agent is not reachable over HTTP, all data comes from the mock
+	// agent client
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "no-such-agent.invalid")
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	client := &mockAgentClient{
+		responses: map[string]interface{}{
+			livenessURL:     ctv.nodeLiveness,
+			interfaceURL:    ctv.nodeInterfaces,
+			bridgeDomainURL: ctv.nodeBridgeDomains,
+			l2FibsURL:       ctv.nodeL2Fibs,
+			arpURL:          ctv.nodeIPArps,
+		},
+	}
+	ctv.telemetryCache.AgentClient = client
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	client.mtx.Lock()
+	gomega.Expect(client.requested).To(gomega.HaveLen(numDTOs))
+	client.mtx.Unlock()
+	gomega.Expect(ctv.report.FilterReport("cleintGet Error")).To(gomega.BeEmpty())
+	expectNodeEntries("404 Not Found", "k8s-master", 2)
+
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeEquivalentTo(ctv.nodeBridgeDomains))
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeEquivalentTo(ctv.nodeL2Fibs))
+	gomega.Expect(node.NodeIPArp).To(gomega.BeEquivalentTo(ctv.nodeIPArps))
+
+	ctv.telemetryCache.AgentClient = nil
+}
+
+func testCollectAgentInfoWithRateLimit(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.telemetryCache.VppCache.CreateNode(2, "k8s-worker1", "10.20.0.10", "127.0.0.1")
+	ctv.telemetryCache.VppCache.CreateNode(3, "k8s-worker2", "10.20.0.11", "127.0.0.1")
+
+	collect := func() time.Duration {
+		start := time.Now()
+		// Kick the telemetryCache to collect & validate data, give it an opportunity
+		// to run and wait for it to complete
+		ctv.tickerChan <- time.Time{}
+		time.Sleep(1 * time.Millisecond)
+		ctv.telemetryCache.waitForValidationToFinish()
+		return time.Since(start)
+	}
+
+	// Global limit: requests to all nodes are spaced at least 1/rate apart
+	ctv.telemetryCache.MaxRequestsPerSecond = 100
+	elapsed := collect()
+	gomega.Expect(elapsed).To(gomega.BeNumerically(">=", (3*numDTOs-1)*10*time.Millisecond))
+	gomega.Expect(ctv.report.FilterReport("rate limiter")).To(gomega.BeEmpty())
+	ctv.telemetryCache.MaxRequestsPerSecond = 0
+
+	// Per-node limit: requests to each node are spaced at least 1/rate
+	// apart, but requests to different nodes are not limited by each other
+	var mtx sync.Mutex
+	requested := make(map[string]int)
+	var snapshot map[string]int
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requested[nodeName]++
+		if requested[nodeName] == 3 && snapshot == nil {
+			// Count the requests made to each node when a node is
+			// requested for the third time
+			snapshot = make(map[string]int)
+			for name, cnt := range requested {
+				snapshot[name] = cnt
+			}
+		}
+	}
+	ctv.telemetryCache.MaxNodeRequestsPerSecond = 50
+	elapsed = collect()
+	gomega.Expect(elapsed).To(gomega.BeNumerically(">=", (numDTOs-1)*20*time.Millisecond))
+	gomega.Expect(ctv.report.FilterReport("rate limiter")).To(gomega.BeEmpty())
+
+	mtx.Lock()
+	gomega.Expect(requested).To(gomega.Equal(map[string]int{
+		"k8s-master": numDTOs, "k8s-worker1": numDTOs, "k8s-worker2": numDTOs}))
+	// All nodes are requested before any node is requested for the third
+	// time, which a limiter shared by the nodes would not allow
+	gomega.Expect(snapshot).To(gomega.HaveLen(3))
+	mtx.Unlock()
+
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.MaxNodeRequestsPerSecond = 0
+}
+
+func testCollectAgentInfoBatch(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.BatchCollect = true
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	var mtx sync.Mutex
+	requested := make(map[string]int)
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requested[url]++
+	}
+	runCycle := func() {
+		mtx.Lock()
+		requested = make(map[string]int)
+		mtx.Unlock()
+
+		// Kick the telemetryCache to collect & validate data, give it an opportunity
+		// to run and wait for it to complete
+		ctv.tickerChan <- time.Time{}
+		time.Sleep(1 * time.Millisecond)
+		ctv.telemetryCache.waitForValidationToFinish()
+	}
+
+	// All data is collected in a single request
+	runCycle()
+	mtx.Lock()
+	gomega.Expect(requested).To(gomega.Equal(map[string]int{batchURL: 1}))
+	mtx.Unlock()
+	gomega.Expect(ctv.report.FilterReport("incomplete data")).To(gomega.BeEmpty())
+	expectNodeEntries("missing in batch payload", "k8s-master", 2)
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeEquivalentTo(ctv.nodeBridgeDomains))
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeEquivalentTo(ctv.nodeL2Fibs))
+	gomega.Expect(node.NodeIPArp).To(gomega.BeEquivalentTo(ctv.nodeIPArps))
+
+	// Batch endpoint not available: fall back to the individual endpoints
+	ctv.injectError = inject404Batch
+	runCycle()
+	mtx.Lock()
+	gomega.Expect(requested[batchURL]).To(gomega.Equal(1))
+	for _, url := range ctv.telemetryCache.URLPaths.nodeDTOURLs() {
+		gomega.Expect(requested[url]).To(gomega.Equal(1))
+	}
+	mtx.Unlock()
+	gomega.Expect(ctv.report.FilterReport("incomplete data")).To(gomega.BeEmpty())
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeEquivalentTo(ctv.nodeBridgeDomains))
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeEquivalentTo(ctv.nodeL2Fibs))
+	gomega.Expect(node.NodeIPArp).To(gomega.BeEquivalentTo(ctv.nodeIPArps))
+
+	ctv.injectError = noError
+	ctv.telemetryCache.OnRequest = nil
+	ctv.telemetryCache.BatchCollect = false
+}
+
+func testCollectionErrorCounts(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "master.invalid")
+	ctv.telemetryCache.VppCache.CreateNode(2, "k8s-worker1", "10.20.0.10", "worker1.invalid")
+
+	ctv.telemetryCache.AgentClient = &mockAgentClient{
+		responses: map[string]interface{}{
+			livenessURL:     ctv.nodeLiveness,
+			interfaceURL:    ctv.nodeInterfaces,
+			bridgeDomainURL: ctv.nodeBridgeDomains,
+			l2FibsURL:       ctv.nodeL2Fibs,
+			arpURL:          ctv.nodeIPArps,
+			staticRouteURL:  []telemetrymodel.NodeIPRoute{},
+			ipamURL:         &telemetrymodel.IPamEntry{},
+		},
+		failHost: "worker1.invalid",
+	}
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	gomega.Expect(ctv.telemetryCache.CollectionErrorCounts()).To(gomega.Equal(map[string]int{
+		"k8s-master":  0,
+		"k8s-worker1": numDTOs,
+	}))
+
+	// Counters are reset in each cycle
+	ctv.telemetryCache.AgentClient.(*mockAgentClient).failHost = ""
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	gomega.Expect(ctv.telemetryCache.CollectionErrorCounts()).To(gomega.Equal(map[string]int{
+		"k8s-master":  0,
+		"k8s-worker1": 0,
+	}))
+
+	ctv.telemetryCache.AgentClient = nil
+}
+
+func TestContivTelemetryCache_GetNodeByPodName(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+	gomega.Expect(testdata.CreateK8sPodTestData(ctc.K8sCache)).To(gomega.Succeed())
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		gomega.Expect(ctc.VppCache.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+
+	for podName, nodeName := range map[string]string{
+		"nginx-768979984b-7lgkl": "k8s-worker2",
+		"nginx-768979984b-8ksk6": "k8s-worker2",
+		"nginx-768979984b-k9b96": "k8s-worker1",
+	} {
+		node, err := ctc.GetNodeByPodName(podName, "default")
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(node.Name).To(gomega.Equal(nodeName))
+	}
+
+	// Unknown pod
+	_, err := ctc.GetNodeByPodName("nginx-768979984b-7lgkl", "kube-system")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+
+	// Unknown node
+	err = ctc.K8sCache.CreatePod("orphan", "default", nil, "10.1.9.2", "10.20.0.99", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+	_, err = ctc.GetNodeByPodName("orphan", "default")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestContivTelemetryCache_DetectMasterNode(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+
+	// No control plane pods
+	_, err := ctc.DetectMasterNode()
+	gomega.Expect(err).NotTo(gomega.BeNil())
+
+	gomega.Expect(testdata.CreateK8sPodTestData(ctc.K8sCache)).To(gomega.Succeed())
+	master, err := ctc.DetectMasterNode()
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(master).To(gomega.Equal("k8s-master"))
+
+	// Control plane pods on multiple nodes
+	err = ctc.K8sCache.CreatePod("kube-apiserver-k8s-worker1", "kube-system", nil,
+		"10.20.0.10", "10.20.0.10", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+	_, err = ctc.DetectMasterNode()
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(err.Error()).To(gomega.ContainSubstring("k8s-master, k8s-worker1"))
+}
+
+func TestContivTelemetryCache_WaitForSync(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{}
+
+	// Not synced yet - the wait times out
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	gomega.Expect(ctc.WaitForSync(ctx)).To(gomega.Equal(context.DeadlineExceeded))
+	cancel()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctc.markSynced()
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	gomega.Expect(ctc.WaitForSync(ctx)).To(gomega.Succeed())
+	gomega.Expect(ctc.Synced).To(gomega.BeTrue())
+
+	// Waiters return immediately once synced
+	gomega.Expect(ctc.WaitForSync(ctx)).To(gomega.Succeed())
+	ctc.markSynced()
+	gomega.Expect(ctc.WaitForSync(ctx)).To(gomega.Succeed())
+}
+
+func TestContivTelemetryCache_FilterVppNodes(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+	err := ctc.VppCache.CreateNode(9, "k8s-worker9", "192.168.16.9/24", "10.20.0.19")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	nodeNames := func(nodes []*telemetrymodel.Node) []string {
+		names := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+
+	workers := ctc.FilterVppNodes(func(node *telemetrymodel.Node) bool {
+		return strings.HasPrefix(node.Name, "k8s-worker")
+	})
+	gomega.Expect(nodeNames(workers)).To(gomega.Equal([]string{"k8s-worker1", "k8s-worker2", "k8s-worker9"}))
+
+	noLiveness := ctc.FilterVppNodes(func(node *telemetrymodel.Node) bool {
+		return node.NodeLiveness == nil
+	})
+	gomega.Expect(nodeNames(noLiveness)).To(gomega.Equal([]string{"k8s-worker9"}))
+
+	none := ctc.FilterVppNodes(func(node *telemetrymodel.Node) bool {
+		return false
+	})
+	gomega.Expect(none).To(gomega.BeEmpty())
+}
+
+func TestContivTelemetryCache_NodesMissingData(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+	gomega.Expect(ctc.NodesMissingData()).To(gomega.BeEmpty())
+
+	// Node with liveness only
+	master, err := ctc.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	err = ctc.VppCache.CreateNode(9, "k8s-worker9", "192.168.16.9/24", "10.20.0.19")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(ctc.VppCache.SetNodeLiveness("k8s-worker9", master.NodeLiveness)).To(gomega.Succeed())
+
+	gomega.Expect(ctc.NodesMissingData()).To(gomega.Equal(map[string][]string{
+		"k8s-worker9": {"NodeInterfaces", "NodeBridgeDomains", "NodeL2Fibs", "NodeIPArp"},
+	}))
+}
+
+func testCollectAgentInfoRetryAfter(t *testing.T) {
+	var mtx sync.Mutex
+	livenessRequests := make([]time.Time, 0)
+
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if url == livenessURL {
+			livenessRequests = append(livenessRequests, time.Now())
+		}
+	}
+
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.throttled = false
+	ctv.injectError = inject429Once
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	mtx.Lock()
+	gomega.Expect(livenessRequests).To(gomega.HaveLen(2))
+	gomega.Expect(livenessRequests[1].Sub(livenessRequests[0])).To(gomega.BeNumerically(">=", time.Second))
+	mtx.Unlock()
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(grep(ctv.logWriter.log, "rate limited by agent")).To(gomega.Equal(1))
+
+	ctv.telemetryCache.OnRequest = nil
+	ctv.injectError = noError
+}
+
+func testCollectNodes(t *testing.T) {
+	var mtx sync.Mutex
+	requestedNodes := make(map[string]bool)
+
+	ctv.telemetryCache.OnRequest = func(nodeName, url string) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		requestedNodes[nodeName] = true
+	}
+
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.httpClientTimeout = clientTimeout * time.Second
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.telemetryCache.VppCache.CreateNode(2, "k8s-worker1", "10.20.0.10", "127.0.0.1")
+
+	workerInterfaces := map[int]telemetrymodel.NodeInterface{
+		1: {If: telemetrymodel.Interface{Name: "GigabitEthernet0/8/0"}},
+	}
+	gomega.Expect(ctv.telemetryCache.VppCache.SetNodeInterfaces("k8s-worker1", workerInterfaces)).To(gomega.Succeed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := ctv.telemetryCache.CollectNodes(ctx, []string{"k8s-master", "k8s-bogus"})
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Only the master node's data was collected
+	mtx.Lock()
+	gomega.Expect(requestedNodes).To(gomega.Equal(map[string]bool{"k8s-master": true}))
+	mtx.Unlock()
+
+	master, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(master.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(master.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	worker, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(worker.NodeInterfaces).To(gomega.BeEquivalentTo(workerInterfaces))
+	gomega.Expect(worker.NodeLiveness).To(gomega.BeNil())
+
+	gomega.Expect(ctv.report.FilterReport("node k8s-bogus not found")).To(gomega.HaveLen(1))
+	gomega.Expect(ctv.report.FilterReport("k8s-worker1 missing")).To(gomega.BeEmpty())
+
+	// No known node to collect from
+	err = ctv.telemetryCache.CollectNodes(ctx, []string{"k8s-bogus"})
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+
+	ctv.telemetryCache.OnRequest = nil
+}
+
+func testCollectAgentInfoSchemaViolation(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+
+	ctv.telemetryCache.AgentClient = &mockAgentClient{
+		responses: map[string]interface{}{
+			livenessURL:     map[string]interface{}{"build_version": "v1.2", "state": "up"},
+			interfaceURL:    ctv.nodeInterfaces,
+			bridgeDomainURL: ctv.nodeBridgeDomains,
+			l2FibsURL:       ctv.nodeL2Fibs,
+			arpURL:          ctv.nodeIPArps,
+			staticRouteURL:  []telemetrymodel.NodeIPRoute{},
+			ipamURL:         &telemetrymodel.IPamEntry{},
+		},
+	}
+	ctv.telemetryCache.ValidateSchemas = true
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.NodeLiveness).To(gomega.BeNil())
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	entries := ctv.report.FilterReport("Invalid data for node")
+	gomega.Expect(entries).To(gomega.HaveLen(1))
+	gomega.Expect(entries[0].NodeName).To(gomega.Equal("k8s-master"))
+	gomega.Expect(entries[0].Message).To(gomega.Equal("Invalid data for node k8s-master from url /liveness: " +
+		"schema violation at field state: expected integer, got string"))
+	gomega.Expect(ctv.report.FilterReport("Error unmarshaling")).To(gomega.BeEmpty())
+
+	ctv.telemetryCache.ValidateSchemas = false
+	ctv.telemetryCache.AgentClient = nil
+}
+
+func testCollectAgentInfoTruncated(t *testing.T) {
+	ctv.logWriter.clearLog()
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "localhost")
+	ctv.injectError = injectTruncated
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.NodeLiveness).To(gomega.BeNil())
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeNil())
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeNil())
+	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeEquivalentTo(ctv.nodeBridgeDomains))
+
+	// Each DTO error is reported once
+	gomega.Expect(ctv.report.FilterReport("truncated response")).To(gomega.ConsistOf(
+		// The connection is closed before the announced body length is read
+		api.ReportEntry{NodeName: "k8s-master",
+			Message: "truncated response from node k8s-master endpoint /liveness (received 23 bytes)"},
+		// The connection is closed in the middle of the JSON document
+		api.ReportEntry{NodeName: "k8s-master",
+			Message: "truncated response from node k8s-master endpoint /vpp/dump/v1/interfaces (received 51 bytes)"},
+	))
+	gomega.Expect(ctv.report.FilterReport("empty response")).To(gomega.ConsistOf(
+		api.ReportEntry{NodeName: "k8s-master",
+			Message: "empty response from node k8s-master endpoint " + l2FibsURL},
+	))
+	gomega.Expect(ctv.report.FilterReport("Error unmarshaling")).To(gomega.BeEmpty())
+
+	ctv.injectError = noError
+}
+
+// writeTruncatedResponse writes a successful response with the beginning
+// of a JSON body and closes the connection. If withLength is set, the
+// response announces a longer body, otherwise the body is delimited by
+// closing the connection.
+func writeTruncatedResponse(w http.ResponseWriter, body string, withLength bool) {
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		ctv.log.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n")
+	if withLength {
+		fmt.Fprintf(buf, "Content-Length: %d\r\n", 2*len(body))
+	} else {
+		buf.WriteString("Connection: close\r\n")
+	}
+	buf.WriteString("\r\n" + body)
+	buf.Flush()
+}
+
+func testCollectAgentInfoNodeURLResolver(t *testing.T) {
+	ctv.telemetryCache.ReinitializeCache()
+	ctv.telemetryCache.VppCache.CreateNode(1, "k8s-master", "10.20.0.2", "10.20.0.2")
+
+	// The node's management IP address is not reachable, all agents are
+	// reached through the mock server
+	resolved := make(chan string, 1)
+	ctv.telemetryCache.NodeURLResolver = func(node *telemetrymodel.Node) string {
+		select {
+		case resolved <- node.Name:
+		default:
+		}
+		return "http://localhost" + testAgentPort + "/"
+	}
+
+	// Kick the telemetryCache to collect & validate data, give it an opportunity
+	// to run and wait for it to complete
+	ctv.tickerChan <- time.Time{}
+	time.Sleep(1 * time.Millisecond)
+	ctv.telemetryCache.waitForValidationToFinish()
+
+	gomega.Expect(<-resolved).To(gomega.Equal("k8s-master"))
+	node, err := ctv.telemetryCache.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(ctv.nodeLiveness))
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeEquivalentTo(ctv.nodeInterfaces))
+
+	ctv.telemetryCache.NodeURLResolver = nil
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	now := time.Date(2018, time.June, 1, 12, 0, 0, 0, time.UTC)
+	defaultDelay := 3 * time.Second
+
+	gomega.Expect(retryAfterDelay("", now, defaultDelay)).To(gomega.Equal(defaultDelay))
+	gomega.Expect(retryAfterDelay("bogus", now, defaultDelay)).To(gomega.Equal(defaultDelay))
+	gomega.Expect(retryAfterDelay("-5", now, defaultDelay)).To(gomega.Equal(defaultDelay))
+	gomega.Expect(retryAfterDelay("7", now, defaultDelay)).To(gomega.Equal(7 * time.Second))
+	gomega.Expect(retryAfterDelay(now.Add(10*time.Second).Format(http.TimeFormat), now, defaultDelay)).
+		To(gomega.Equal(10 * time.Second))
+	gomega.Expect(retryAfterDelay(now.Add(-10*time.Second).Format(http.TimeFormat), now, defaultDelay)).
+		To(gomega.Equal(time.Duration(0)))
+}
+
+func TestContivTelemetryCache_InterfaceTypeHistogram(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+	}
+	gomega.Expect(ctc.InterfaceTypeHistogram()).To(gomega.BeEmpty())
+
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+
+	// The sample topology has 3 nodes, each with a host tap, 2 VXLAN tunnels
+	// and the vxlanBVI loopback; 4 pod taps are spread across the nodes. The
+	// local0 interface on each node is reported with the default (loopback)
+	// type.
+	histogram := ctc.InterfaceTypeHistogram()
+	gomega.Expect(histogram[interfaces.InterfaceType_TAP_INTERFACE]).To(gomega.Equal(7))
+	gomega.Expect(histogram[interfaces.InterfaceType_VXLAN_TUNNEL]).To(gomega.Equal(6))
+	gomega.Expect(histogram[interfaces.InterfaceType_SOFTWARE_LOOPBACK]).To(gomega.Equal(6))
+	gomega.Expect(histogram[interfaces.InterfaceType_ETHERNET_CSMACD]).To(gomega.Equal(3))
+}
+
+func TestContivTelemetryCache_PodTapMapping(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+	gomega.Expect(testdata.CreateK8sPodTestData(ctc.K8sCache)).To(gomega.Succeed())
+	for _, node := range ctc.VppCache.RetrieveAllNodes() {
+		gomega.Expect(ctc.VppCache.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+
+	mapping := ctc.PodTapMapping()
+	gomega.Expect(mapping).To(gomega.HaveLen(len(ctc.K8sCache.RetrieveAllPods())))
+
+	podTaps := make(map[string]PodTap)
+	for _, podTap := range mapping {
+		podTaps[podTap.PodName] = podTap
+	}
+	gomega.Expect(podTaps["nginx-768979984b-7lgkl"]).To(gomega.Equal(PodTap{PodName: "nginx-768979984b-7lgkl",
+		Namespace: "default", IPAddress: "10.1.3.2", NodeName: "k8s-worker2", Tap: "tap811df001bf1cba6", TapIfIndex: 6}))
+	gomega.Expect(podTaps["nginx-768979984b-8ksk6"]).To(gomega.Equal(PodTap{PodName: "nginx-768979984b-8ksk6",
+		Namespace: "default", IPAddress: "10.1.3.3", NodeName: "k8s-worker2", Tap: "tap50d452ae66521e0", TapIfIndex: 7}))
+	gomega.Expect(podTaps["nginx-768979984b-k9b96"]).To(gomega.Equal(PodTap{PodName: "nginx-768979984b-k9b96",
+		Namespace: "default", IPAddress: "10.1.2.2", NodeName: "k8s-worker1", Tap: "tapdd404f36cc4f794", TapIfIndex: 6}))
+
+	// Host network pods are not served by a tap interface
+	gomega.Expect(podTaps["kube-proxy-ctntg"]).To(gomega.Equal(PodTap{PodName: "kube-proxy-ctntg",
+		Namespace: "kube-system", IPAddress: "10.20.0.10", NodeName: "k8s-worker1"}))
+
+	// A tap interface with the pod IP address serves the pod
+	worker1, err := ctc.VppCache.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	tap := worker1.NodeInterfaces[6]
+	tap.If.IPAddresses = []string{"10.1.2.2/32"}
+	worker1.NodeInterfaces[6] = tap
+	worker1.NodeIPam = nil
+	for _, podTap := range ctc.PodTapMapping() {
+		if podTap.PodName == "nginx-768979984b-k9b96" {
+			gomega.Expect(podTap.Tap).To(gomega.Equal("tapdd404f36cc4f794"))
+		}
+	}
+
+	// Pods on unknown nodes are listed without a node and a tap
+	ctc.K8sCache.CreatePod("nginx-768979984b-zzzzz", "default", nil, "10.1.9.2", "10.20.0.99", nil)
+	for _, podTap := range ctc.PodTapMapping() {
+		if podTap.PodName == "nginx-768979984b-zzzzz" {
+			gomega.Expect(podTap).To(gomega.Equal(PodTap{PodName: "nginx-768979984b-zzzzz",
+				Namespace: "default", IPAddress: "10.1.9.2"}))
+		}
+	}
+}
+
+func TestContivTelemetryCache_ExportNodesSnapshot(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	ctc := &ContivTelemetryCache{
+		Deps: Deps{
+			Log: logrus.NewLogger("snapshot-test"),
+		},
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+	}
+	gomega.Expect(testdata.CreateNodeTestData(ctc.VppCache)).To(gomega.Succeed())
+	gomega.Expect(testdata.CreateK8sPodTestData(ctc.K8sCache)).To(gomega.Succeed())
+
+	buf, err := ctc.ExportNodesSnapshot([]string{"k8s-master", "k8s-worker2", "k8s-bogus"})
+	gomega.Expect(err).To(gomega.BeNil())
+
+	snapshot := NodesSnapshot{}
+	gomega.Expect(json.Unmarshal(buf, &snapshot)).To(gomega.Succeed())
+	nodeNames := make([]string, 0)
+	for _, node := range snapshot.Nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	gomega.Expect(nodeNames).To(gomega.Equal([]string{"k8s-master", "k8s-worker2"}))
+
+	podNames := make(map[string]bool)
+	for _, pod := range snapshot.Pods {
+		gomega.Expect(pod.HostIPAddress).NotTo(gomega.Equal("10.20.0.10"))
+		podNames[pod.Name] = true
+	}
+	gomega.Expect(podNames).To(gomega.HaveKey("kube-dns-86f4d74b45-tx7td"))
+	gomega.Expect(podNames).To(gomega.HaveKey("nginx-768979984b-7lgkl"))
+	gomega.Expect(podNames).NotTo(gomega.HaveKey("nginx-768979984b-k9b96"))
+	gomega.Expect(string(buf)).NotTo(gomega.ContainSubstring("k8s-worker1"))
+}
+
+// expectNodeEntries asserts that the report has n entries containing substr
+// and that all of them were recorded for the node nodeName.
+func expectNodeEntries(substr, nodeName string, n int) {
+	entries := ctv.report.FilterReport(substr)
+	gomega.Expect(entries).To(gomega.HaveLen(n))
+	for _, entry := range entries {
+		gomega.Expect(entry.NodeName).To(gomega.Equal(nodeName))
+	}
+}
+
 func grep(output []string, pattern string) int {
 	cnt := 0
 	for _, l := range output {
@@ -243,15 +243,15 @@ type NodeTelemetry struct {
 
 //Output holds the unmarshalled node telemetry output
 type Output struct {
-	command string
-	output  []OutputEntry
+	Command string        `json:"command"`
+	Output  []OutputEntry `json:"output"`
 }
 
 //OutputEntry holds the unmarshalled node output telemetry data
 type OutputEntry struct {
-	nodeName string
-	count    int
-	reason   string
+	NodeName string `json:"node_name"`
+	Count    int    `json:"count"`
+	Reason   string `json:"reason"`
 }
 
 //Pod contains pod parameter data
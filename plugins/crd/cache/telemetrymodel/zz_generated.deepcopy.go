@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 // Copyright (c) 2018 Cisco and/or its affiliates.
@@ -591,8 +592,8 @@ func (in *NodeTelemetry) DeepCopy() *NodeTelemetry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
-	if in.output != nil {
-		in, out := &in.output, &out.output
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
 		*out = make([]OutputEntry, len(*in))
 		copy(*out, *in)
 	}
@@ -29,6 +29,10 @@ type K8sDataStore struct {
 	lock       *sync.Mutex
 	k8sNodeMap map[string]*node.Node
 	podMap     map[string]*telemetrymodel.Pod
+
+	// secondary pod indices
+	podHostIPMap map[string]map[string]*telemetrymodel.Pod
+	podIPMap     map[string]*telemetrymodel.Pod
 }
 
 // NewK8sDataStore will return a pointer to a new cache which holds various
@@ -38,6 +42,8 @@ func NewK8sDataStore() *K8sDataStore {
 		&sync.Mutex{},
 		make(map[string]*node.Node),
 		make(map[string]*telemetrymodel.Pod),
+		make(map[string]map[string]*telemetrymodel.Pod),
+		make(map[string]*telemetrymodel.Pod),
 	}
 }
 
@@ -99,6 +105,40 @@ func (k *K8sDataStore) DeleteK8sNode(name string) error {
 	return nil
 }
 
+// DeleteK8sNodeAndPods deletes the k8s node with the given name together
+// with all pods hosted on the node, i.e. pods whose host IP address is one
+// of the node's InternalIP addresses. It returns the names of the deleted
+// pods.
+func (k *K8sDataStore) DeleteK8sNodeAndPods(name string) ([]string, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k8sNode, err := k.retrieveK8sNode(name)
+	if err != nil {
+		return nil, errors.Errorf("k8s node with name %+v not found", name)
+	}
+	delete(k.k8sNodeMap, name)
+
+	pList := make([]*telemetrymodel.Pod, 0)
+	for _, adr := range k8sNode.Addresses {
+		if adr.Type != node.NodeAddress_NodeInternalIP {
+			continue
+		}
+		for key, pod := range k.podHostIPMap[adr.Address] {
+			k.removePodFromIndices(key, pod)
+			delete(k.podMap, key)
+			pList = append(pList, pod)
+		}
+	}
+	sortPods(pList)
+
+	podNames := make([]string, 0, len(pList))
+	for _, pod := range pList {
+		podNames = append(podNames, pod.Name)
+	}
+	return podNames, nil
+}
+
 // RetrieveAllK8sNodes returns a list of all nodes in the data store.
 func (k *K8sDataStore) RetrieveAllK8sNodes() []*node.Node {
 	k.lock.Lock()
@@ -135,21 +175,50 @@ func (k *K8sDataStore) CreatePod(name, Namespace string, label []*pod2.Pod_Label
 		IPAddress:     IPAddress,
 		HostIPAddress: hostIPAddress,
 	}
-	_, ok := k.podMap[name]
+	key := podKey(name, Namespace)
+	_, ok := k.podMap[key]
 	if ok {
-		return errors.Errorf("Duplicate pod with name %+v found", name)
+		return errors.Errorf("Duplicate pod with name %+v in namespace %+v found", name, Namespace)
 	}
-	k.podMap[name] = &newPod
+	k.podMap[key] = &newPod
+	k.addPodToIndices(key, &newPod)
 	return nil
 }
 
-// RetrievePod will retrieve a pod from the cache with the given name or
-// return an error if it is not found.
-func (k *K8sDataStore) RetrievePod(name string) (*telemetrymodel.Pod, error) {
+// RetrievePod will retrieve a pod from the cache with the given name and
+// namespace or return an error if it is not found.
+func (k *K8sDataStore) RetrievePod(name string, namespace string) (*telemetrymodel.Pod, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.retrievePod(podKey(name, namespace))
+}
+
+// RetrievePodsByHostIPAddr returns all pods running on the host with the
+// given IP address.
+func (k *K8sDataStore) RetrievePodsByHostIPAddr(hostIPAddress string) []*telemetrymodel.Pod {
 	k.lock.Lock()
 	defer k.lock.Unlock()
 
-	return k.retrievePod(name)
+	pList := make([]*telemetrymodel.Pod, 0)
+	for _, p := range k.podHostIPMap[hostIPAddress] {
+		pList = append(pList, p)
+	}
+	sortPods(pList)
+	return pList
+}
+
+// RetrievePodByIPAddr will retrieve the pod with the given IP address from
+// the cache or return an error if it is not found.
+func (k *K8sDataStore) RetrievePodByIPAddr(IPAddress string) (*telemetrymodel.Pod, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	pod, ok := k.podIPMap[IPAddress]
+	if !ok {
+		return nil, errors.Errorf("Pod with IP address %+v not found", IPAddress)
+	}
+	return pod, nil
 }
 
 // UpdatePod updates the specified pod in the K8s cache. If the pod
@@ -159,28 +228,32 @@ func (k *K8sDataStore) UpdatePod(name string, namespace string, label []*telemet
 	k.lock.Lock()
 	defer k.lock.Unlock()
 
-	pod, err := k.retrievePod(name)
+	key := podKey(name, namespace)
+	pod, err := k.retrievePod(key)
 	if err != nil {
-		return errors.Errorf("Cannot find pod %+v in k8s cache pod map", name)
+		return errors.Errorf("Cannot find pod %+v in namespace %+v in k8s cache pod map", name, namespace)
 	}
+	k.removePodFromIndices(key, pod)
 	pod.Label = label
-	pod.Namespace = namespace
 	pod.IPAddress = IPAddress
 	pod.HostIPAddress = hostIPAddress
+	k.addPodToIndices(key, pod)
 	return nil
 }
 
 // DeletePod deletes the specified pod from the K8s cache. If the pod
 // is found, the pod is deleted; otherwise, an error is returned.
-func (k *K8sDataStore) DeletePod(name string) error {
+func (k *K8sDataStore) DeletePod(name string, namespace string) error {
 	k.lock.Lock()
 	defer k.lock.Unlock()
 
-	_, err := k.retrievePod(name)
+	key := podKey(name, namespace)
+	pod, err := k.retrievePod(key)
 	if err != nil {
-		return errors.Errorf("pod with name %+v not found", name)
+		return errors.Errorf("pod with name %+v in namespace %+v not found", name, namespace)
 	}
-	delete(k.podMap, name)
+	k.removePodFromIndices(key, pod)
+	delete(k.podMap, key)
 	return nil
 }
 
@@ -189,19 +262,56 @@ func (k *K8sDataStore) RetrieveAllPods() []*telemetrymodel.Pod {
 	k.lock.Lock()
 	defer k.lock.Unlock()
 
-	var str []string
-	for k := range k.podMap {
-		str = append(str, k)
-	}
 	var nList []*telemetrymodel.Pod
-	sort.Strings(str)
-	for _, v := range str {
-		p, _ := k.retrievePod(v)
+	for _, p := range k.podMap {
 		nList = append(nList, p)
 	}
+	sortPods(nList)
 	return nList
 }
 
+// Merge imports all nodes and pods from another K8s data store. If prefix
+// is not empty, it is prepended to the names of the imported nodes and pods
+// to avoid collisions with the nodes and pods already in the data store.
+// If any imported node or pod collides with an existing one, an error is
+// returned and nothing is imported.
+func (k *K8sDataStore) Merge(other *K8sDataStore, prefix string) error {
+	if other == k {
+		return errors.Errorf("cannot merge k8s data store into itself")
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	other.lock.Lock()
+	defer other.lock.Unlock()
+
+	for name := range other.k8sNodeMap {
+		if _, ok := k.k8sNodeMap[prefix+name]; ok {
+			return errors.Errorf("Duplicate k8s node with name %+v found", prefix+name)
+		}
+	}
+	for _, pod := range other.podMap {
+		if _, ok := k.podMap[podKey(prefix+pod.Name, pod.Namespace)]; ok {
+			return errors.Errorf("Duplicate pod with name %+v in namespace %+v found",
+				prefix+pod.Name, pod.Namespace)
+		}
+	}
+
+	for name, n := range other.k8sNodeMap {
+		newNode := *n
+		newNode.Name = prefix + name
+		k.k8sNodeMap[newNode.Name] = &newNode
+	}
+	for _, pod := range other.podMap {
+		newPod := *pod
+		newPod.Name = prefix + pod.Name
+		key := podKey(newPod.Name, newPod.Namespace)
+		k.podMap[key] = &newPod
+		k.addPodToIndices(key, &newPod)
+	}
+	return nil
+}
+
 // ReinitializeCache will clear all data from the data store
 func (k *K8sDataStore) ReinitializeCache() {
 	k.lock.Lock()
@@ -209,6 +319,8 @@ func (k *K8sDataStore) ReinitializeCache() {
 
 	k.podMap = make(map[string]*telemetrymodel.Pod)
 	k.k8sNodeMap = make(map[string]*node.Node)
+	k.podHostIPMap = make(map[string]map[string]*telemetrymodel.Pod)
+	k.podIPMap = make(map[string]*telemetrymodel.Pod)
 }
 
 // retrieveK8sNode is an internal function (no locks) used to retrieve
@@ -223,12 +335,58 @@ func (k *K8sDataStore) retrieveK8sNode(name string) (*node.Node, error) {
 }
 
 // retrievePod is an internal function (no locks) used to retrieve
-// a node from the data store map. It should be called with the global
-// cache lock locked.
-func (k *K8sDataStore) retrievePod(name string) (*telemetrymodel.Pod, error) {
-	pod, ok := k.podMap[name]
+// a pod from the data store map by its key (see podKey()). It should be
+// called with the global cache lock locked.
+func (k *K8sDataStore) retrievePod(key string) (*telemetrymodel.Pod, error) {
+	pod, ok := k.podMap[key]
 	if !ok {
-		return nil, errors.Errorf("Pod with name %+v not found", name)
+		return nil, errors.Errorf("Pod with key %+v not found", key)
 	}
 	return pod, nil
 }
+
+// addPodToIndices adds the pod to the secondary pod indices. It should be
+// called with the global cache lock locked.
+func (k *K8sDataStore) addPodToIndices(key string, pod *telemetrymodel.Pod) {
+	if pod.HostIPAddress != "" {
+		if k.podHostIPMap[pod.HostIPAddress] == nil {
+			k.podHostIPMap[pod.HostIPAddress] = make(map[string]*telemetrymodel.Pod)
+		}
+		k.podHostIPMap[pod.HostIPAddress][key] = pod
+	}
+	// Pods in the host network share the host's IP address, so only pods
+	// with their own IP address are indexed by IP address
+	if pod.IPAddress != "" && pod.IPAddress != pod.HostIPAddress {
+		k.podIPMap[pod.IPAddress] = pod
+	}
+}
+
+// removePodFromIndices removes the pod from the secondary pod indices. It
+// should be called with the global cache lock locked.
+func (k *K8sDataStore) removePodFromIndices(key string, pod *telemetrymodel.Pod) {
+	if hostPods, ok := k.podHostIPMap[pod.HostIPAddress]; ok {
+		delete(hostPods, key)
+		if len(hostPods) == 0 {
+			delete(k.podHostIPMap, pod.HostIPAddress)
+		}
+	}
+	if p, ok := k.podIPMap[pod.IPAddress]; ok && p == pod {
+		delete(k.podIPMap, pod.IPAddress)
+	}
+}
+
+// podKey returns the key under which a pod is stored in the data store.
+// Pod names are unique only within a namespace, so the key includes both.
+func podKey(name string, namespace string) string {
+	return namespace + "/" + name
+}
+
+// sortPods sorts pods by name and then by namespace.
+func sortPods(pods []*telemetrymodel.Pod) {
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Name != pods[j].Name {
+			return pods[i].Name < pods[j].Name
+		}
+		return pods[i].Namespace < pods[j].Namespace
+	})
+}
@@ -15,6 +15,7 @@
 package datastore
 
 import (
+	"github.com/contiv/vpp/plugins/crd/testdata"
 	"github.com/contiv/vpp/plugins/ksr/model/node"
 	pod2 "github.com/contiv/vpp/plugins/ksr/model/pod"
 	"github.com/onsi/gomega"
@@ -54,12 +55,15 @@ func TestK8sDataStore_CreatePod(t *testing.T) {
 
 	labels := []*pod2.Pod_Label{{Key: "123", Value: "431"}}
 	db.CreatePod("k8s-pod1", "namespace1", labels, "1.2.3.4", "hostip", nil)
-	pod, err := db.RetrievePod("k8s-pod1")
+	pod, err := db.RetrievePod("k8s-pod1", "namespace1")
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
 
-	err = db.CreatePod("k8s-pod1", "", nil, "", "", nil)
+	err = db.CreatePod("k8s-pod1", "namespace1", nil, "", "", nil)
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+
+	err = db.CreatePod("k8s-pod1", "namespace2", nil, "", "", nil)
+	gomega.Expect(err).To(gomega.BeNil())
 }
 
 func TestK8sDataStore_DeleteK8sNode(t *testing.T) {
@@ -79,21 +83,91 @@ func TestK8sDataStore_DeleteK8sNode(t *testing.T) {
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 }
 
+func TestK8sDataStore_DeleteK8sNodeAndPods(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewK8sDataStore()
+	err := testdata.CreateK8sNodeTestData(db)
+	gomega.Expect(err).To(gomega.BeNil())
+	err = testdata.CreateK8sPodTestData(db)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	workerPods := db.RetrievePodsByHostIPAddr("10.20.0.10")
+	gomega.Expect(workerPods).NotTo(gomega.BeEmpty())
+	masterPodCnt := len(db.RetrievePodsByHostIPAddr("10.20.0.2"))
+	gomega.Expect(masterPodCnt).NotTo(gomega.BeZero())
+
+	podNames, err := db.DeleteK8sNodeAndPods("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(podNames).To(gomega.HaveLen(len(workerPods)))
+	for i, pod := range workerPods {
+		gomega.Expect(podNames[i]).To(gomega.Equal(pod.Name))
+		_, err := db.RetrievePod(pod.Name, pod.Namespace)
+		gomega.Expect(err).NotTo(gomega.BeNil())
+	}
+
+	_, err = db.RetrieveK8sNode("k8s-worker1")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(db.RetrievePodsByHostIPAddr("10.20.0.10")).To(gomega.BeEmpty())
+
+	// Pods on other nodes survive
+	gomega.Expect(db.RetrievePodsByHostIPAddr("10.20.0.2")).To(gomega.HaveLen(masterPodCnt))
+
+	_, err = db.DeleteK8sNodeAndPods("blah")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+}
+
 func TestK8sDataStore_DeletePod(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewK8sDataStore()
 	db.CreatePod("k8s-pod1", "namespace1", nil, "1.2.3.4", "hostip", nil)
-	pod, err := db.retrievePod("k8s-pod1")
+	pod, err := db.retrievePod(podKey("k8s-pod1", "namespace1"))
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
 
-	err = db.DeletePod("k8s-pod1")
+	err = db.DeletePod("k8s-pod1", "namespace1")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	err = db.DeletePod("blah", "namespace1")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+
+}
+func TestK8sDataStore_DeleteSamplePod(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewK8sDataStore()
+	err := testdata.CreateK8sPodTestData(db)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	pod, err := db.RetrievePod("kube-dns-86f4d74b45-tx7td", "kube-system")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(pod.HostIPAddress).To(gomega.Equal("10.20.0.2"))
+	gomega.Expect(db.RetrievePodsByHostIPAddr("10.20.0.2")).To(gomega.ContainElement(pod))
+	hostPodCnt := len(db.RetrievePodsByHostIPAddr("10.20.0.2"))
+
+	ipPod, err := db.RetrievePodByIPAddr("10.1.1.2")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(ipPod).To(gomega.Equal(pod))
+
+	// Pods are keyed by namespace and name
+	_, err = db.RetrievePod("kube-dns-86f4d74b45-tx7td", "default")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	err = db.DeletePod("kube-dns-86f4d74b45-tx7td", "default")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+
+	err = db.DeletePod("kube-dns-86f4d74b45-tx7td", "kube-system")
 	gomega.Expect(err).To(gomega.BeNil())
 
-	err = db.DeletePod("blah")
+	_, err = db.RetrievePod("kube-dns-86f4d74b45-tx7td", "kube-system")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(db.podMap).NotTo(gomega.HaveKey(podKey("kube-dns-86f4d74b45-tx7td", "kube-system")))
+	gomega.Expect(db.RetrievePodsByHostIPAddr("10.20.0.2")).NotTo(gomega.ContainElement(pod))
+	gomega.Expect(db.RetrievePodsByHostIPAddr("10.20.0.2")).To(gomega.HaveLen(hostPodCnt - 1))
+	_, err = db.RetrievePodByIPAddr("10.1.1.2")
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 
+	err = db.DeletePod("kube-dns-86f4d74b45-tx7td", "kube-system")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 }
+
 func TestK8sDataStore_RetrieveK8sNode(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewK8sDataStore()
@@ -113,11 +187,11 @@ func TestK8sDataStore_RetrievePod(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewK8sDataStore()
 	db.CreatePod("k8s-pod1", "namespace1", nil, "1.2.3.4", "hostip", nil)
-	pod, err := db.RetrievePod("k8s-pod1")
+	pod, err := db.RetrievePod("k8s-pod1", "namespace1")
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
 
-	_, err = db.RetrievePod("blah")
+	_, err = db.RetrievePod("blah", "namespace1")
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 }
 
@@ -145,15 +219,20 @@ func TestK8sDataStore_UpdatePod(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewK8sDataStore()
 	db.CreatePod("k8s-pod1", "namespace1", nil, "1.2.3.4", "hostip", nil)
-	pod, err := db.retrievePod("k8s-pod1")
+	pod, err := db.retrievePod(podKey("k8s-pod1", "namespace1"))
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
 
-	db.UpdatePod(pod.Name, "oekfe", nil, "4.32.1", "", nil)
-	pod, err = db.retrievePod("k8s-pod1")
+	db.UpdatePod(pod.Name, pod.Namespace, nil, "4.32.1", "hostip2", nil)
+	pod, err = db.retrievePod(podKey("k8s-pod1", "namespace1"))
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
-	gomega.Expect(pod.Namespace).To(gomega.BeEquivalentTo("oekfe"))
+	gomega.Expect(pod.IPAddress).To(gomega.BeEquivalentTo("4.32.1"))
+	gomega.Expect(db.podHostIPMap).NotTo(gomega.HaveKey("hostip"))
+	gomega.Expect(db.podHostIPMap["hostip2"]).To(gomega.HaveKey(podKey("k8s-pod1", "namespace1")))
+
+	err = db.UpdatePod("k8s-pod1", "oekfe", nil, "", "", nil)
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 
 	err = db.UpdatePod("bla", "", nil, "", "", nil)
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
@@ -182,7 +261,7 @@ func TestK8sDataStore_RetrieveAllPods(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewK8sDataStore()
 	db.CreatePod("k8s-pod1", "namespace1", nil, "1.2.3.4", "hostip", nil)
-	pod, err := db.retrievePod("k8s-pod1")
+	pod, err := db.retrievePod(podKey("k8s-pod1", "namespace1"))
 	gomega.Expect(err).To(gomega.BeNil())
 	gomega.Expect(pod.Name).To(gomega.BeEquivalentTo("k8s-pod1"))
 
@@ -209,3 +288,50 @@ func TestK8sDataStore_ReinitializeCache(t *testing.T) {
 	node, err = db.retrieveK8sNode("k8s-master")
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 }
+
+func TestK8sDataStore_Merge(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db1 := NewK8sDataStore()
+	err := testdata.CreateK8sNodeTestData(db1)
+	gomega.Expect(err).To(gomega.BeNil())
+	err = testdata.CreateK8sPodTestData(db1)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	db2 := NewK8sDataStore()
+	err = testdata.CreateK8sNodeTestData(db2)
+	gomega.Expect(err).To(gomega.BeNil())
+	err = testdata.CreateK8sPodTestData(db2)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	nodeCnt := len(db1.RetrieveAllK8sNodes())
+	podCnt := len(db1.RetrieveAllPods())
+
+	// Without a prefix, the stores' nodes and pods collide
+	err = db1.Merge(db2, "")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(db1.RetrieveAllK8sNodes()).To(gomega.HaveLen(nodeCnt))
+	gomega.Expect(db1.RetrieveAllPods()).To(gomega.HaveLen(podCnt))
+
+	err = db1.Merge(db1, "cluster2-")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+
+	err = db1.Merge(db2, "cluster2-")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(db1.RetrieveAllK8sNodes()).To(gomega.HaveLen(2 * nodeCnt))
+	gomega.Expect(db1.RetrieveAllPods()).To(gomega.HaveLen(2 * podCnt))
+
+	node, err := db1.RetrieveK8sNode("cluster2-k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.Name).To(gomega.Equal("cluster2-k8s-master"))
+	_, err = db1.RetrieveK8sNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	pod, err := db1.RetrievePod("cluster2-kube-dns-86f4d74b45-tx7td", "kube-system")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(db1.RetrievePodsByHostIPAddr(pod.HostIPAddress)).To(gomega.ContainElement(pod))
+
+	// The source store is not modified
+	gomega.Expect(db2.RetrieveAllK8sNodes()).To(gomega.HaveLen(nodeCnt))
+	_, err = db2.RetrieveK8sNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+}
@@ -15,14 +15,24 @@
 package datastore
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/contiv/vpp/plugins/crd/api"
 	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
 	"github.com/ligato/cn-infra/logging"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
+// globalReportFile is the name of the file into which ExportReportsByNode
+// writes the global messages; no node is exported into a file of this name.
+const globalReportFile = "global.txt"
+
 // SimpleReport holds error/warning messages recorded during data collection /
 // validation
 type SimpleReport struct {
@@ -30,14 +40,31 @@ type SimpleReport struct {
 	Data      telemetrymodel.Reports
 	Output    io.Writer
 	TimeStamp time.Time
+
+	// MinSeverity is the lowest severity of entries recorded in the
+	// report; entries with a lower severity are dropped. All entries are
+	// recorded by default.
+	MinSeverity api.Severity
+
+	// severities holds the severity of each entry in Data
+	severities map[string][]api.Severity
+	// categories holds the category of each entry in Data; entries
+	// recorded without a category have an empty category
+	categories map[string][]string
+
+	history     []api.ReportSnapshot
+	historySize int
 }
 
-// NewSimpleReport creates a new SimpleReport instance
-func NewSimpleReport(log logging.Logger) *SimpleReport {
+// NewSimpleReport creates a new SimpleReport instance. historySize is the
+// number of report snapshots retained in the report history.
+func NewSimpleReport(log logging.Logger, historySize int) *SimpleReport {
 	return &SimpleReport{
-		Log:    log,
-		Data:   make(telemetrymodel.Reports),
-		Output: os.Stdout,
+		Log:         log,
+		Data:        make(telemetrymodel.Reports),
+		Output:      os.Stdout,
+		history:     make([]api.ReportSnapshot, 0, historySize),
+		historySize: historySize,
 	}
 }
 
@@ -46,24 +73,203 @@ func (r *SimpleReport) RetrieveReport() telemetrymodel.Reports {
 	return r.Data
 }
 
+// FilterReport returns all report entries that contain the specified
+// substring. Entries are ordered by node name and, within a node, in the
+// order in which they were recorded.
+func (r *SimpleReport) FilterReport(substr string) []api.ReportEntry {
+	nodeNames := make([]string, 0, len(r.Data))
+	for nodeName := range r.Data {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	entries := make([]api.ReportEntry, 0)
+	for _, nodeName := range nodeNames {
+		for _, line := range r.Data[nodeName] {
+			if strings.Contains(line, substr) {
+				entries = append(entries, api.ReportEntry{NodeName: nodeName, Message: line})
+			}
+		}
+	}
+	return entries
+}
+
+// GlobalMessages returns the entries recorded into the global report bin
+// (api.GlobalMsg), in the order in which they were recorded.
+func (r *SimpleReport) GlobalMessages() []api.ReportEntry {
+	entries := make([]api.ReportEntry, 0, len(r.Data[api.GlobalMsg]))
+	for _, line := range r.Data[api.GlobalMsg] {
+		entries = append(entries, api.ReportEntry{NodeName: api.GlobalMsg, Message: line})
+	}
+	return entries
+}
+
+// CountByCategory returns the number of report entries recorded with each
+// category. Entries recorded without a category and validation summaries
+// (api.CategorySummary) are not counted.
+func (r *SimpleReport) CountByCategory() map[string]int {
+	counts := make(map[string]int)
+	for _, categories := range r.categories {
+		for _, category := range categories {
+			if category != "" && category != api.CategorySummary {
+				counts[category]++
+			}
+		}
+	}
+	return counts
+}
+
+// CountBySeverity returns the number of report entries recorded with each
+// severity. Validation summaries (api.CategorySummary) are not counted.
+func (r *SimpleReport) CountBySeverity() map[api.Severity]int {
+	counts := make(map[api.Severity]int)
+	for nodeName, severities := range r.severities {
+		for i, severity := range severities {
+			if r.categories[nodeName][i] != api.CategorySummary {
+				counts[severity]++
+			}
+		}
+	}
+	return counts
+}
+
+// ReportDiff holds the differences between a baseline report and a current
+// report.
+type ReportDiff struct {
+	// Regressions are the entries present in the current report, but not
+	// in the baseline
+	Regressions []api.ReportEntry
+	// Resolved are the entries present in the baseline, but not in the
+	// current report
+	Resolved []api.ReportEntry
+}
+
+// DiffReports compares the current report with the baseline report.
+// Entries are matched by node name and message; an entry recorded several
+// times for a node is matched as many times as it occurs in the other
+// report. Entries are ordered as in FilterReport.
+func DiffReports(baseline, current *SimpleReport) ReportDiff {
+	return ReportDiff{
+		Regressions: subtractEntries(current.FilterReport(""), baseline.FilterReport("")),
+		Resolved:    subtractEntries(baseline.FilterReport(""), current.FilterReport("")),
+	}
+}
+
+// subtractEntries returns the entries in a that are not matched by an
+// entry in b.
+func subtractEntries(a, b []api.ReportEntry) []api.ReportEntry {
+	counts := make(map[api.ReportEntry]int)
+	for _, entry := range b {
+		counts[entry]++
+	}
+
+	diff := make([]api.ReportEntry, 0)
+	for _, entry := range a {
+		if counts[entry] > 0 {
+			counts[entry]--
+			continue
+		}
+		diff = append(diff, entry)
+	}
+	return diff
+}
+
+// PushSnapshot saves a copy of the current report and its time stamp into
+// the report history. If the history is full, the oldest snapshot is
+// evicted.
+func (r *SimpleReport) PushSnapshot() {
+	if r.historySize <= 0 {
+		return
+	}
+	if len(r.history) == r.historySize {
+		r.history = append(r.history[:0], r.history[1:]...)
+	}
+	r.history = append(r.history, api.ReportSnapshot{
+		TimeStamp: r.TimeStamp,
+		Data:      r.Data.DeepCopy(),
+	})
+}
+
+// History returns the retained report snapshots, oldest first.
+func (r *SimpleReport) History() []api.ReportSnapshot {
+	history := make([]api.ReportSnapshot, len(r.history))
+	copy(history, r.history)
+	return history
+}
+
 // LogErrAndAppendToNodeReport log an error and appends the string to
-// the status log
+// the status log. The error is logged as a structured log line with the
+// node, category and severity fields.
 func (r *SimpleReport) LogErrAndAppendToNodeReport(nodeName string, errString string) {
-	r.AppendToNodeReport(nodeName, errString)
-	r.Log.Errorf(errString)
+	r.LogErrAndAppendToNodeReportWithCategory(nodeName, "", errString)
+}
+
+// LogErrAndAppendToNodeReportWithCategory logs an error and appends the
+// string tagged with the category to the status log.
+func (r *SimpleReport) LogErrAndAppendToNodeReportWithCategory(nodeName string, category string,
+	errString string) {
+	r.Append(nodeName, api.SeverityError, category, errString)
+	r.Log.WithFields(logFields(nodeName, api.SeverityError, category)).Error(errString)
+}
+
+// logFields returns the structured log fields for a report entry. The
+// category field is omitted for entries recorded without a category.
+func logFields(nodeName string, severity api.Severity, category string) logging.Fields {
+	fields := logging.Fields{
+		"node":     nodeName,
+		"severity": severity.String(),
+	}
+	if category != "" {
+		fields["category"] = category
+	}
+	return fields
 }
 
-// AppendToNodeReport appends the string to the status log
+// AppendToNodeReport appends the error string to the status log
 func (r *SimpleReport) AppendToNodeReport(nodeName string, errString string) {
+	r.Append(nodeName, api.SeverityError, "", errString)
+}
+
+// AppendToNodeReportWithCategory appends the error string tagged with the
+// category to the status log
+func (r *SimpleReport) AppendToNodeReportWithCategory(nodeName string, category string, errString string) {
+	r.Append(nodeName, api.SeverityError, category, errString)
+}
+
+// AppendToNodeReportWithSeverity appends the string to the status log,
+// unless its severity is lower than the report's MinSeverity
+func (r *SimpleReport) AppendToNodeReportWithSeverity(nodeName string, severity api.Severity, msg string) {
+	r.Append(nodeName, severity, "", msg)
+}
+
+// Append appends the string with its severity and category to the status
+// log, unless its severity is lower than the report's MinSeverity. An
+// empty category records the entry without a category.
+func (r *SimpleReport) Append(nodeName string, severity api.Severity, category string, msg string) {
+	if severity < r.MinSeverity {
+		return
+	}
 	if r.Data[nodeName] == nil {
 		r.Data[nodeName] = make([]string, 0)
 	}
-	r.Data[nodeName] = append(r.Data[nodeName], errString)
+	r.Data[nodeName] = append(r.Data[nodeName], msg)
+
+	if r.severities == nil {
+		r.severities = make(map[string][]api.Severity)
+	}
+	r.severities[nodeName] = append(r.severities[nodeName], severity)
+
+	if r.categories == nil {
+		r.categories = make(map[string][]string)
+	}
+	r.categories[nodeName] = append(r.categories[nodeName], category)
 }
 
 // Clear clears the status log
 func (r *SimpleReport) Clear() {
 	r.Data = make(map[string][]string)
+	r.severities = make(map[string][]api.Severity)
+	r.categories = make(map[string][]string)
 }
 
 // Print prints the status log
@@ -80,6 +286,127 @@ func (r *SimpleReport) Print() {
 	}
 }
 
+// ReportToMarkdown renders the status log as Markdown, with a table of
+// severities and messages for the global messages followed by a table for
+// each node.
+func (r *SimpleReport) ReportToMarkdown() string {
+	nodeNames := make([]string, 0, len(r.Data))
+	for nodeName := range r.Data {
+		if nodeName != api.GlobalMsg {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+	sort.Strings(nodeNames)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "# Validation Report")
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "Time-stamp: %s\n", r.GetTimeStamp())
+	if _, ok := r.Data[api.GlobalMsg]; ok {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, "## Global")
+		r.writeMarkdownTable(buf, api.GlobalMsg)
+	}
+	for _, nodeName := range nodeNames {
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, "## Node %s\n", nodeName)
+		r.writeMarkdownTable(buf, nodeName)
+	}
+	return buf.String()
+}
+
+// writeMarkdownTable writes the entries recorded for the node as a
+// Markdown table. Entries without a recorded severity are errors.
+func (r *SimpleReport) writeMarkdownTable(buf *bytes.Buffer, nodeName string) {
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "| Severity | Message |")
+	fmt.Fprintln(buf, "|----------|---------|")
+	severities := r.severities[nodeName]
+	for i, line := range r.Data[nodeName] {
+		severity := api.SeverityError
+		if i < len(severities) {
+			severity = severities[i]
+		}
+		msg := strings.Replace(line, "|", "\\|", -1)
+		msg = strings.Replace(msg, "\n", " ", -1)
+		fmt.Fprintf(buf, "| %s | %s |\n", severity, msg)
+	}
+}
+
+// ExportReportsByNode writes the entries recorded for each node into a
+// separate file <nodeName>.txt in dir, one entry per line. The global
+// messages are written into global.txt, a name reserved for them.
+// Characters unsafe for file names are replaced with '_' in node names; if
+// the file name of a node is already taken, i.e. global.txt or the file of
+// a node whose sanitized name is the same, a suffix -2, -3, ... is appended
+// to it. Nodes are assigned file names in the order of their names. The
+// directory is created if it does not exist.
+func (r *SimpleReport) ExportReportsByNode(dir string) error {
+	nodeNames := make([]string, 0, len(r.Data))
+	for nodeName := range r.Data {
+		if nodeName != api.GlobalMsg {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+
+	files := make(map[string]*bytes.Buffer)
+	if lines, ok := r.Data[api.GlobalMsg]; ok {
+		files[globalReportFile] = &bytes.Buffer{}
+		for _, line := range lines {
+			fmt.Fprintln(files[globalReportFile], line)
+		}
+	}
+	for nodeName, fileName := range nodeReportFileNames(nodeNames) {
+		files[fileName] = &bytes.Buffer{}
+		for _, line := range r.Data[nodeName] {
+			fmt.Fprintln(files[fileName], line)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for fileName, buf := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, fileName), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeReportFileNames assigns a report file name to each of the nodes, as
+// described in ExportReportsByNode, and returns the file names keyed by
+// node name.
+func nodeReportFileNames(nodeNames []string) map[string]string {
+	sorted := append([]string(nil), nodeNames...)
+	sort.Strings(sorted)
+
+	fileNames := make(map[string]string)
+	usedNames := map[string]bool{globalReportFile: true}
+	for _, nodeName := range sorted {
+		base := sanitizeFileName(nodeName)
+		fileName := base + ".txt"
+		for i := 2; usedNames[fileName]; i++ {
+			fileName = fmt.Sprintf("%s-%d.txt", base, i)
+		}
+		usedNames[fileName] = true
+		fileNames[nodeName] = fileName
+	}
+	return fileNames
+}
+
+// sanitizeFileName replaces all characters other than ASCII letters,
+// digits, '.', '-' and '_' in name with '_'.
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
 //SetTimeStamp sets the reports timestamp based on the time passed.
 func (r *SimpleReport) SetTimeStamp(time time.Time) {
 	r.TimeStamp = time
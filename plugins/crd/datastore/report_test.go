@@ -15,15 +15,22 @@
 package datastore
 
 import (
+	"bytes"
+	"fmt"
+	"github.com/contiv/vpp/plugins/crd/api"
 	"github.com/ligato/cn-infra/logging/logrus"
 	"github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestSimpleReport_AppendToNodeReport(t *testing.T) {
 	gomega.RegisterTestingT(t)
-	report := NewSimpleReport(logrus.DefaultLogger())
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
 	report.LogErrAndAppendToNodeReport("nodeName", "ErrorString")
 	report.SetTimeStamp(time.Now())
 
@@ -39,3 +46,269 @@ func TestSimpleReport_AppendToNodeReport(t *testing.T) {
 	report.Print()
 
 }
+
+func TestSimpleReport_FilterReport(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+	report.AppendToNodeReport("k8s-worker1", "failed to get data: 404 Not Found")
+	report.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	report.AppendToNodeReport("k8s-master", "failed to get data: 404 Not Found")
+	report.AppendToNodeReport(api.GlobalMsg, "BD validation: OK")
+	report.AppendToNodeReport("k8s-master", "another 404 Not Found")
+
+	entries := report.FilterReport("404 Not Found")
+	gomega.Expect(entries).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: "k8s-master", Message: "failed to get data: 404 Not Found"},
+		{NodeName: "k8s-master", Message: "another 404 Not Found"},
+		{NodeName: "k8s-worker1", Message: "failed to get data: 404 Not Found"},
+	}))
+
+	entries = report.FilterReport("Timeout exceeded")
+	gomega.Expect(entries).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: "k8s-master", Message: "Timeout exceeded"},
+	}))
+
+	gomega.Expect(report.FilterReport("no such message")).To(gomega.BeEmpty())
+}
+
+func TestSimpleReport_CountByCategory(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+	gomega.Expect(report.CountByCategory()).To(gomega.BeEmpty())
+
+	report.AppendToNodeReportWithCategory("k8s-master", api.CategoryVxlan, "VXLAN tunnel vxlan1 is disabled")
+	report.AppendToNodeReportWithCategory("k8s-worker1", api.CategoryVxlan, "VXLAN tunnel vxlan2 is disabled")
+	report.LogErrAndAppendToNodeReportWithCategory("k8s-worker1", api.CategoryL2Fib, "invalid L2Fib entry")
+	report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryVxlan, "VXLAN mesh incomplete")
+	report.AppendToNodeReport("k8s-master", "failed to get data: 404 Not Found")
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "BD validation: OK")
+	report.Append("k8s-worker2", api.SeverityWarning, api.CategoryPods, "pod has no tap interface")
+
+	gomega.Expect(report.CountByCategory()).To(gomega.Equal(map[string]int{
+		api.CategoryVxlan: 3,
+		api.CategoryL2Fib: 1,
+		api.CategoryPods:  1,
+	}))
+	gomega.Expect(report.Data["k8s-worker1"]).To(gomega.Equal([]string{
+		"VXLAN tunnel vxlan2 is disabled", "invalid L2Fib entry"}))
+
+	// Clearing the report resets the counts
+	report.Clear()
+	gomega.Expect(report.CountByCategory()).To(gomega.BeEmpty())
+	report.AppendToNodeReportWithCategory("k8s-master", api.CategoryArp, "missing ARP entry")
+	gomega.Expect(report.CountByCategory()).To(gomega.Equal(map[string]int{api.CategoryArp: 1}))
+}
+
+func TestSimpleReport_CountBySeverity(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+	gomega.Expect(report.CountBySeverity()).To(gomega.BeEmpty())
+
+	report.AppendToNodeReportWithCategory("k8s-master", api.CategoryVxlan, "VXLAN tunnel vxlan1 is disabled")
+	report.AppendToNodeReport("k8s-worker1", "failed to get data: 404 Not Found")
+	report.Append("k8s-worker1", api.SeverityWarning, api.CategoryPods, "pod has no tap interface")
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "cluster size: 3 VPP nodes")
+	report.Append(api.GlobalMsg, api.SeverityError, api.CategorySummary, "VXLAN validation: 1 error found")
+	report.Append(api.GlobalMsg, api.SeverityInfo, api.CategorySummary, "BD validation: OK")
+
+	gomega.Expect(report.CountBySeverity()).To(gomega.Equal(map[api.Severity]int{
+		api.SeverityError:   2,
+		api.SeverityWarning: 1,
+		api.SeverityInfo:    1,
+	}))
+	gomega.Expect(report.CountByCategory()).To(gomega.Equal(map[string]int{
+		api.CategoryVxlan: 1,
+		api.CategoryPods:  1,
+	}))
+}
+
+func TestDiffReports(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	baseline := NewSimpleReport(logrus.DefaultLogger(), 0)
+	baseline.AppendToNodeReport(api.GlobalMsg, "BD validation: OK")
+	baseline.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	baseline.AppendToNodeReport("k8s-worker1", "failed to get data: 404 Not Found")
+
+	current := NewSimpleReport(logrus.DefaultLogger(), 0)
+	current.AppendToNodeReport(api.GlobalMsg, "BD validation: OK")
+	current.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	current.AppendToNodeReport("k8s-worker1", "failed to get data: 404 Not Found")
+
+	diff := DiffReports(baseline, current)
+	gomega.Expect(diff.Regressions).To(gomega.BeEmpty())
+	gomega.Expect(diff.Resolved).To(gomega.BeEmpty())
+
+	// A new error surfaces as a single regression
+	current.AppendToNodeReport("k8s-worker2", "Timeout exceeded")
+
+	diff = DiffReports(baseline, current)
+	gomega.Expect(diff.Regressions).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: "k8s-worker2", Message: "Timeout exceeded"},
+	}))
+	gomega.Expect(diff.Resolved).To(gomega.BeEmpty())
+
+	// Entries are matched by node and message, repeated entries are counted
+	current.Clear()
+	current.AppendToNodeReport(api.GlobalMsg, "BD validation: OK")
+	current.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	current.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	current.AppendToNodeReport("k8s-worker2", "failed to get data: 404 Not Found")
+
+	diff = DiffReports(baseline, current)
+	gomega.Expect(diff.Regressions).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: "k8s-master", Message: "Timeout exceeded"},
+		{NodeName: "k8s-worker2", Message: "failed to get data: 404 Not Found"},
+	}))
+	gomega.Expect(diff.Resolved).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: "k8s-worker1", Message: "failed to get data: 404 Not Found"},
+	}))
+}
+
+func TestSimpleReport_History(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 2)
+	gomega.Expect(report.History()).To(gomega.BeEmpty())
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		report.Clear()
+		report.AppendToNodeReport("k8s-master", fmt.Sprintf("run %d", i))
+		report.SetTimeStamp(start.Add(time.Duration(i) * time.Minute))
+		report.PushSnapshot()
+	}
+
+	// The oldest snapshot has been evicted
+	history := report.History()
+	gomega.Expect(history).To(gomega.HaveLen(2))
+	gomega.Expect(history[0].TimeStamp).To(gomega.Equal(start.Add(1 * time.Minute)))
+	gomega.Expect(history[0].Data["k8s-master"]).To(gomega.Equal([]string{"run 1"}))
+	gomega.Expect(history[1].TimeStamp).To(gomega.Equal(start.Add(2 * time.Minute)))
+	gomega.Expect(history[1].Data["k8s-master"]).To(gomega.Equal([]string{"run 2"}))
+
+	// Snapshots are not affected by subsequent changes to the report
+	report.AppendToNodeReport("k8s-master", "late entry")
+	gomega.Expect(report.History()[1].Data["k8s-master"]).To(gomega.Equal([]string{"run 2"}))
+}
+
+func TestSimpleReport_MinSeverity(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+
+	// All entries are recorded by default
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "BD validation: OK")
+	report.AppendToNodeReportWithSeverity("k8s-master", api.SeverityWarning, "suspicious entry")
+	report.AppendToNodeReport("k8s-master", "invalid entry")
+	gomega.Expect(report.Data[api.GlobalMsg]).To(gomega.Equal([]string{"BD validation: OK"}))
+	gomega.Expect(report.Data["k8s-master"]).To(gomega.Equal([]string{"suspicious entry", "invalid entry"}))
+
+	// Entries below the minimum severity are dropped
+	report.Clear()
+	report.MinSeverity = api.SeverityError
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "BD validation: OK")
+	report.AppendToNodeReportWithSeverity("k8s-master", api.SeverityWarning, "suspicious entry")
+	report.AppendToNodeReport("k8s-master", "invalid entry")
+	report.LogErrAndAppendToNodeReport("k8s-worker1", "another invalid entry")
+	gomega.Expect(report.Data).NotTo(gomega.HaveKey(api.GlobalMsg))
+	gomega.Expect(report.Data["k8s-master"]).To(gomega.Equal([]string{"invalid entry"}))
+	gomega.Expect(report.Data["k8s-worker1"]).To(gomega.Equal([]string{"another invalid entry"}))
+}
+
+func TestSimpleReport_StructuredLog(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	logOutput := &bytes.Buffer{}
+	log := logrus.NewLogger("report-test")
+	log.SetOutput(logOutput)
+
+	report := NewSimpleReport(log, 0)
+	report.LogErrAndAppendToNodeReport("k8s-master", "missing ARP entry")
+	gomega.Expect(report.Data["k8s-master"]).To(gomega.Equal([]string{"missing ARP entry"}))
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("missing ARP entry"))
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("node=k8s-master"))
+	gomega.Expect(logOutput.String()).NotTo(gomega.ContainSubstring("category="))
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("severity=error"))
+
+	logOutput.Reset()
+	report.LogErrAndAppendToNodeReport(api.GlobalMsg, "invalid subnet")
+	gomega.Expect(report.Data[api.GlobalMsg]).To(gomega.Equal([]string{"invalid subnet"}))
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("node=global"))
+	gomega.Expect(logOutput.String()).NotTo(gomega.ContainSubstring("category="))
+
+	logOutput.Reset()
+	report.LogErrAndAppendToNodeReportWithCategory("k8s-worker1", api.CategoryArp, "invalid ARP entry")
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("node=k8s-worker1"))
+	gomega.Expect(logOutput.String()).To(gomega.ContainSubstring("category=arp"))
+}
+
+func TestSimpleReport_ReportToMarkdown(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "BD validation: OK")
+	report.AppendToNodeReport(api.GlobalMsg, "ARP validation: 1 error found")
+	report.AppendToNodeReport("k8s-master", "missing ARP entry | 10.20.0.10")
+	report.AppendToNodeReportWithSeverity("k8s-worker1", api.SeverityWarning, "suspicious entry")
+
+	md := report.ReportToMarkdown()
+	gomega.Expect(md).To(gomega.HavePrefix("# Validation Report\n"))
+	gomega.Expect(md).To(gomega.ContainSubstring("## Global\n\n| Severity | Message |\n|----------|---------|\n" +
+		"| info | BD validation: OK |\n| error | ARP validation: 1 error found |\n"))
+	gomega.Expect(md).To(gomega.ContainSubstring("## Node k8s-master\n\n| Severity | Message |\n"))
+	gomega.Expect(md).To(gomega.ContainSubstring("| error | missing ARP entry \\| 10.20.0.10 |\n"))
+	gomega.Expect(md).To(gomega.ContainSubstring("## Node k8s-worker1\n\n| Severity | Message |\n"))
+	gomega.Expect(md).To(gomega.ContainSubstring("| warning | suspicious entry |\n"))
+	gomega.Expect(strings.Index(md, "## Global")).To(gomega.BeNumerically("<", strings.Index(md, "## Node k8s-master")))
+	gomega.Expect(strings.Index(md, "## Node k8s-master")).To(gomega.BeNumerically("<", strings.Index(md, "## Node k8s-worker1")))
+}
+
+func TestSimpleReport_ExportReportsByNode(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	report := NewSimpleReport(logrus.DefaultLogger(), 0)
+	report.AppendToNodeReport("k8s-master", "Timeout exceeded")
+	report.AppendToNodeReport("k8s-master", "failed to get data: 404 Not Found")
+	report.AppendToNodeReport("k8s-worker1", "invalid entry")
+	report.AppendToNodeReport("../k8s worker2", "unsafe node name")
+	report.AppendToNodeReport("../k8s_worker2", "colliding node name")
+	report.AppendToNodeReportWithSeverity(api.GlobalMsg, api.SeverityInfo, "BD validation: OK")
+
+	dir, err := ioutil.TempDir("", "reports")
+	gomega.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "nodes")
+
+	gomega.Expect(report.ExportReportsByNode(dir)).To(gomega.Succeed())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(files).To(gomega.ConsistOf(
+		filepath.Join(dir, "global.txt"),
+		filepath.Join(dir, "k8s-master.txt"),
+		filepath.Join(dir, "k8s-worker1.txt"),
+		filepath.Join(dir, ".._k8s_worker2.txt"),
+		filepath.Join(dir, ".._k8s_worker2-2.txt"),
+	))
+
+	expected := map[string]string{
+		"global.txt":           "BD validation: OK\n",
+		"k8s-master.txt":       "Timeout exceeded\nfailed to get data: 404 Not Found\n",
+		"k8s-worker1.txt":      "invalid entry\n",
+		".._k8s_worker2.txt":   "unsafe node name\n",
+		".._k8s_worker2-2.txt": "colliding node name\n",
+	}
+	for fileName, content := range expected {
+		buf, err := ioutil.ReadFile(filepath.Join(dir, fileName))
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(string(buf)).To(gomega.Equal(content))
+	}
+}
+
+func TestNodeReportFileNames(t *testing.T) {
+	gomega.RegisterTestingT(t)
+
+	// global.txt is reserved for the global messages
+	gomega.Expect(nodeReportFileNames([]string{"k8s-master", "global", "../k8s worker2", "../k8s_worker2"})).To(
+		gomega.Equal(map[string]string{
+			"k8s-master":     "k8s-master.txt",
+			"global":         "global-2.txt",
+			"../k8s worker2": ".._k8s_worker2.txt",
+			"../k8s_worker2": ".._k8s_worker2-2.txt",
+		}))
+}
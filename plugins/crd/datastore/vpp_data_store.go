@@ -15,7 +15,9 @@
 package datastore
 
 import (
+	"context"
 	"fmt"
+	"github.com/contiv/vpp/plugins/crd/api"
 	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
 	"github.com/pkg/errors"
 	"sort"
@@ -32,6 +34,10 @@ type VppDataStore struct {
 	GigEIPMap  map[string]*telemetrymodel.Node
 	LoopMACMap map[string]*telemetrymodel.Node
 	HostIPMap  map[string]*telemetrymodel.Node
+
+	// nodeWaiters holds the channels of callers blocked in WaitForNode,
+	// keyed by the name of the node they are waiting for.
+	nodeWaiters map[string][]chan *telemetrymodel.Node
 }
 
 // CreateNode will add a node to the node cache with the given parameters,
@@ -51,6 +57,11 @@ func (vds *VppDataStore) CreateNode(ID uint32, nodeName, IPAddr, ManIPAdr string
 	ipa := strings.Split(IPAddr, "/")
 	vds.GigEIPMap[ipa[0]] = n
 
+	for _, waiter := range vds.nodeWaiters[nodeName] {
+		waiter <- n
+	}
+	delete(vds.nodeWaiters, nodeName)
+
 	return nil
 }
 
@@ -67,6 +78,54 @@ func (vds *VppDataStore) RetrieveNode(nodeName string) (n *telemetrymodel.Node,
 	return nil, fmt.Errorf("node %s not found", nodeName)
 }
 
+// RetrieveNodeCopy returns a deep copy of the node with the given name,
+// which the caller can safely read and modify without affecting the data
+// store. The copy is a snapshot; it does not reflect later updates of the
+// node in the data store.
+func (vds *VppDataStore) RetrieveNodeCopy(nodeName string) (*telemetrymodel.Node, error) {
+	vds.lock.Lock()
+	defer vds.lock.Unlock()
+
+	node, ok := vds.retrieveNode(nodeName)
+	if ok {
+		return node.DeepCopy(), nil
+	}
+	return nil, fmt.Errorf("node %s not found", nodeName)
+}
+
+// WaitForNode returns a pointer to the node with the given name. If the node
+// is not yet in the cache, WaitForNode blocks until the node is created or
+// until the context expires, in which case the context error is returned.
+func (vds *VppDataStore) WaitForNode(ctx context.Context, nodeName string) (*telemetrymodel.Node, error) {
+	vds.lock.Lock()
+	if node, ok := vds.retrieveNode(nodeName); ok {
+		vds.lock.Unlock()
+		return node, nil
+	}
+	waiter := make(chan *telemetrymodel.Node, 1)
+	vds.nodeWaiters[nodeName] = append(vds.nodeWaiters[nodeName], waiter)
+	vds.lock.Unlock()
+
+	select {
+	case node := <-waiter:
+		return node, nil
+	case <-ctx.Done():
+		vds.lock.Lock()
+		defer vds.lock.Unlock()
+		waiters := vds.nodeWaiters[nodeName]
+		for i, w := range waiters {
+			if w == waiter {
+				vds.nodeWaiters[nodeName] = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(vds.nodeWaiters[nodeName]) == 0 {
+			delete(vds.nodeWaiters, nodeName)
+		}
+		return nil, ctx.Err()
+	}
+}
+
 // DeleteNode handles node deletions from the cache. If the node identified
 // by 'nodeName" is present in the cache, it is deleted and nil error is
 // returned; otherwise, an error is returned.
@@ -130,6 +189,36 @@ func (vds *VppDataStore) UpdateNode(ID uint32, nodeName, IPAdr, ManIPAdr string)
 	return nil
 }
 
+// ResetNodeData clears the data collected from the specified node, so that
+// it is re-populated in the next data collection cycle. The node remains in
+// the cache; its secondary indices derived from the collected data are
+// removed. Returns an error if the node does not exist.
+func (vds *VppDataStore) ResetNodeData(nodeName string) error {
+	vds.lock.Lock()
+	defer vds.lock.Unlock()
+
+	node, ok := vds.retrieveNode(nodeName)
+	if !ok {
+		return fmt.Errorf("failed to reset data for node %s - node not found", nodeName)
+	}
+
+	node.NodeLiveness = nil
+	node.NodeInterfaces = nil
+	node.NodeBridgeDomains = nil
+	node.NodeL2Fibs = nil
+	node.NodeTelemetry = nil
+	node.NodeIPArp = nil
+
+	for _, index := range []map[string]*telemetrymodel.Node{vds.LoopMACMap, vds.LoopIPMap, vds.HostIPMap} {
+		for key, n := range index {
+			if n == node {
+				delete(index, key)
+			}
+		}
+	}
+	return nil
+}
+
 //ClearCache with clear all vpp cache data except for the base NodeMap that contains
 // the discovered nodes..
 func (vds *VppDataStore) ClearCache() {
@@ -166,6 +255,8 @@ func NewVppDataStore() (n *VppDataStore) {
 		GigEIPMap:  make(map[string]*telemetrymodel.Node),
 		LoopMACMap: make(map[string]*telemetrymodel.Node),
 		HostIPMap:  make(map[string]*telemetrymodel.Node),
+
+		nodeWaiters: make(map[string][]chan *telemetrymodel.Node),
 	}
 }
 
@@ -191,6 +282,10 @@ func (vds *VppDataStore) SetNodeInterfaces(nodeName string, nInt map[int]telemet
 	if !ok {
 		return fmt.Errorf("failed to set NodeInterfaces for node %s", nodeName)
 	}
+	if nInt == nil {
+		// keep received data distinguishable from data never collected
+		nInt = make(map[int]telemetrymodel.NodeInterface)
+	}
 	node.NodeInterfaces = nInt
 	return nil
 
@@ -218,6 +313,10 @@ func (vds *VppDataStore) SetNodeBridgeDomain(nodeName string, nBridge map[int]te
 	if !ok {
 		return fmt.Errorf("failed to set NodeBridgeDomains for node %s", nodeName)
 	}
+	if nBridge == nil {
+		// keep received data distinguishable from data never collected
+		nBridge = make(map[int]telemetrymodel.NodeBridgeDomain)
+	}
 	node.NodeBridgeDomains = nBridge
 	return nil
 }
@@ -231,6 +330,10 @@ func (vds *VppDataStore) SetNodeL2Fibs(nodeName string, nL2F map[string]telemetr
 	if !ok {
 		return fmt.Errorf("failed to set NodeL2Fibs for node %s", nodeName)
 	}
+	if nL2F == nil {
+		// keep received data distinguishable from data never collected
+		nL2F = make(map[string]telemetrymodel.NodeL2FibEntry)
+	}
 	node.NodeL2Fibs = nL2F
 	return nil
 }
@@ -257,6 +360,10 @@ func (vds *VppDataStore) SetNodeIPARPs(nodeName string, nArps []telemetrymodel.N
 	if !ok {
 		return fmt.Errorf("failed to set NodeIPArp for node %s", nodeName)
 	}
+	if nArps == nil {
+		// keep received data distinguishable from data never collected
+		nArps = []telemetrymodel.NodeIPArpEntry{}
+	}
 	node.NodeIPArp = nArps
 	return nil
 
@@ -283,11 +390,15 @@ func (vds *VppDataStore) SetSecondaryNodeIndices(node *telemetrymodel.Node) []st
 	vds.lock.Lock()
 	defer vds.lock.Unlock()
 
+	return vds.setSecondaryNodeIndices(node)
+}
+
+func (vds *VppDataStore) setSecondaryNodeIndices(node *telemetrymodel.Node) []string {
 	errReport := make([]string, 0)
 
 	loopIF, err := GetNodeLoopIFInfo(node)
 	if err != nil {
-		errReport = append(errReport, "node %s does not have a loop interface", node.Name)
+		errReport = append(errReport, fmt.Sprintf("node %s does not have a loop interface", node.Name))
 		return errReport
 	}
 
@@ -325,40 +436,197 @@ func (vds *VppDataStore) SetSecondaryNodeIndices(node *telemetrymodel.Node) []st
 	return errReport
 }
 
+// ValidateIndexIntegrity checks that the secondary node indices are in sync
+// with the node map: each node must be reachable through its GigE IP
+// address and, once its secondary indices are set (see
+// SetSecondaryNodeIndices), through its host IP address and its loop
+// interface IP and MAC addresses. No index may point to a node that is not
+// in the node map. The list of integrity violations found is returned.
+func (vds *VppDataStore) ValidateIndexIntegrity() []string {
+	vds.lock.Lock()
+	defer vds.lock.Unlock()
+
+	violations := make([]string, 0)
+	checkIndex := func(indexName string, index map[string]*telemetrymodel.Node, key string,
+		node *telemetrymodel.Node) {
+		if n, ok := index[key]; !ok || n != node {
+			violations = append(violations,
+				fmt.Sprintf("node %s not found in %s index under key %s", node.Name, indexName, key))
+		}
+	}
+
+	nodeNames := make([]string, 0, len(vds.NodeMap))
+	for nodeName := range vds.NodeMap {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		node := vds.NodeMap[nodeName]
+		checkIndex(api.GigEIPIndex, vds.GigEIPMap, strings.Split(node.IPAddr, "/")[0], node)
+
+		loopIF, err := GetNodeLoopIFInfo(node)
+		if err != nil {
+			continue
+		}
+		checkIndex(api.HostIPIndex, vds.HostIPMap, node.ManIPAddr, node)
+		for _, ipAddr := range loopIF.If.IPAddresses {
+			checkIndex(api.LoopIPIndex, vds.LoopIPMap, ipAddr, node)
+		}
+		checkIndex(api.LoopMACIndex, vds.LoopMACMap, loopIF.If.PhysAddress, node)
+	}
+
+	for _, index := range []struct {
+		name    string
+		entries map[string]*telemetrymodel.Node
+	}{
+		{api.GigEIPIndex, vds.GigEIPMap},
+		{api.HostIPIndex, vds.HostIPMap},
+		{api.LoopIPIndex, vds.LoopIPMap},
+		{api.LoopMACIndex, vds.LoopMACMap},
+	} {
+		keys := make([]string, 0, len(index.entries))
+		for key := range index.entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			node := index.entries[key]
+			if n, ok := vds.NodeMap[node.Name]; !ok || n != node {
+				violations = append(violations,
+					fmt.Sprintf("%s index key %s points to deleted node %s", index.name, key, node.Name))
+			}
+		}
+	}
+
+	return violations
+}
+
+// RebuildIndexes clears the secondary node indices and repopulates them
+// from the node map. Host IP, loop IP and loop MAC entries are only created
+// for nodes whose interfaces have been collected. The duplicate or empty
+// addresses found while rebuilding the indices are returned.
+func (vds *VppDataStore) RebuildIndexes() []string {
+	vds.lock.Lock()
+	defer vds.lock.Unlock()
+
+	vds.GigEIPMap = make(map[string]*telemetrymodel.Node)
+	vds.LoopMACMap = make(map[string]*telemetrymodel.Node)
+	vds.LoopIPMap = make(map[string]*telemetrymodel.Node)
+	vds.HostIPMap = make(map[string]*telemetrymodel.Node)
+
+	nodeNames := make([]string, 0, len(vds.NodeMap))
+	for nodeName := range vds.NodeMap {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	errReport := make([]string, 0)
+	for _, nodeName := range nodeNames {
+		node := vds.NodeMap[nodeName]
+		vds.GigEIPMap[strings.Split(node.IPAddr, "/")[0]] = node
+
+		if _, err := GetNodeLoopIFInfo(node); err != nil {
+			continue
+		}
+		errReport = append(errReport, vds.setSecondaryNodeIndices(node)...)
+	}
+	return errReport
+}
+
+// RetrieveNodeByIndex returns a reference to node data for the specified
+// key in the secondary index selected by indexName. Valid index names are
+// api.GigEIPIndex, api.HostIPIndex, api.LoopIPIndex, api.LoopMACIndex and
+// api.ManIPIndex.
+func (vds *VppDataStore) RetrieveNodeByIndex(indexName string, key string) (*telemetrymodel.Node, error) {
+	var index map[string]*telemetrymodel.Node
+	var keyType string
+
+	switch indexName {
+	case api.GigEIPIndex:
+		index, keyType = vds.GigEIPMap, "GigE IP address"
+	case api.HostIPIndex, api.ManIPIndex:
+		index, keyType = vds.HostIPMap, "Host IP address"
+	case api.LoopIPIndex:
+		index, keyType = vds.LoopIPMap, "Loop IP address"
+	case api.LoopMACIndex:
+		index, keyType = vds.LoopMACMap, "Loop MAC address"
+	default:
+		return nil, fmt.Errorf("unknown node index '%s'", indexName)
+	}
+
+	if node, ok := index[key]; ok {
+		return node, nil
+	}
+	return nil, fmt.Errorf("node for %s %s not found", keyType, key)
+}
+
 // RetrieveNodeByHostIPAddr returns a reference to node dat for the specified
 // management (host) IP address.
 func (vds *VppDataStore) RetrieveNodeByHostIPAddr(ipAddr string) (*telemetrymodel.Node, error) {
-	if node, ok := vds.HostIPMap[ipAddr]; ok {
-		return node, nil
-	}
-	return nil, fmt.Errorf("node for Host IP address %s not found", ipAddr)
+	return vds.RetrieveNodeByIndex(api.HostIPIndex, ipAddr)
 }
 
 // RetrieveNodeByLoopMacAddr returns a reference to node dat for the specified
 // loopback Loop0 MAC address.
 func (vds *VppDataStore) RetrieveNodeByLoopMacAddr(macAddress string) (*telemetrymodel.Node, error) {
-	if node, ok := vds.LoopMACMap[macAddress]; ok {
-		return node, nil
-	}
-	return nil, fmt.Errorf("node for Loop MAC address %s not found", macAddress)
+	return vds.RetrieveNodeByIndex(api.LoopMACIndex, macAddress)
 }
 
 // RetrieveNodeByLoopIPAddr returns a reference to node dat for the specified
 // loopback Loop0 IP address.
 func (vds *VppDataStore) RetrieveNodeByLoopIPAddr(ipAddress string) (*telemetrymodel.Node, error) {
-	if node, ok := vds.LoopIPMap[ipAddress]; ok {
-		return node, nil
-	}
-	return nil, fmt.Errorf("node for Loop IP address %s not found", ipAddress)
+	return vds.RetrieveNodeByIndex(api.LoopIPIndex, ipAddress)
 }
 
 // RetrieveNodeByGigEIPAddr returns a reference to node dat for the specified
 // VPP GigE IP address.
 func (vds *VppDataStore) RetrieveNodeByGigEIPAddr(ipAddress string) (*telemetrymodel.Node, error) {
-	if node, ok := vds.GigEIPMap[ipAddress]; ok {
-		return node, nil
+	return vds.RetrieveNodeByIndex(api.GigEIPIndex, ipAddress)
+}
+
+// RetrieveNodeByAnyIP returns a reference to the node that owns the
+// specified IP address on any of its interfaces (GigE, loop, tap, ...).
+// The prefix length, if any, is ignored. An error is returned if no node or
+// more than one node owns the address.
+func (vds *VppDataStore) RetrieveNodeByAnyIP(ip string) (*telemetrymodel.Node, error) {
+	vds.lock.Lock()
+	defer vds.lock.Unlock()
+
+	ip = strings.Split(ip, "/")[0]
+	owners := make([]*telemetrymodel.Node, 0)
+	for _, node := range vds.NodeMap {
+		if nodeOwnsIP(node, ip) {
+			owners = append(owners, node)
+		}
+	}
+
+	switch len(owners) {
+	case 0:
+		return nil, fmt.Errorf("node for IP address %s not found", ip)
+	case 1:
+		return owners[0], nil
 	}
-	return nil, fmt.Errorf("node for GigE IP address %s not found", ipAddress)
+	names := make([]string, 0, len(owners))
+	for _, node := range owners {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("IP address %s is ambiguous, owned by nodes %s", ip, strings.Join(names, ", "))
+}
+
+// nodeOwnsIP returns true if the IP address is configured on any of the
+// node's interfaces.
+func nodeOwnsIP(node *telemetrymodel.Node, ip string) bool {
+	for _, intf := range node.NodeInterfaces {
+		for _, ifIP := range intf.If.IPAddresses {
+			if strings.Split(ifIP, "/")[0] == ip {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GetNodeLoopIFInfo gets the loop interface for the given node
@@ -368,10 +636,35 @@ func GetNodeLoopIFInfo(node *telemetrymodel.Node) (*telemetrymodel.NodeInterface
 			return &ifs, nil
 		}
 	}
-	err := errors.Errorf("loop interface not found", node.Name)
+	err := errors.Errorf("loop interface not found on node %s", node.Name)
 	return nil, err
 }
 
+// MissingNodeData returns the names of the node data required for
+// validation (liveness, interfaces, BDs, L2FIBs and ARPs) that have not been
+// received for the node. Tables that were received but are empty, e.g. the
+// L2FIB or ARP table of the only node in a cluster, are not missing. The node
+// data is complete if none is missing.
+func MissingNodeData(node *telemetrymodel.Node) []string {
+	missing := make([]string, 0)
+	if node.NodeLiveness == nil {
+		missing = append(missing, "liveness")
+	}
+	if node.NodeInterfaces == nil {
+		missing = append(missing, "interfaces")
+	}
+	if node.NodeBridgeDomains == nil {
+		missing = append(missing, "BDs")
+	}
+	if node.NodeL2Fibs == nil {
+		missing = append(missing, "L2FIBs")
+	}
+	if node.NodeIPArp == nil {
+		missing = append(missing, "ARPs")
+	}
+	return missing
+}
+
 // retrieveNode returns a pointer to a node for the given key.
 // Returns an error if that key is not found.
 func (vds *VppDataStore) retrieveNode(key string) (*telemetrymodel.Node, bool) {
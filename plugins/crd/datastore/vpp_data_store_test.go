@@ -15,10 +15,15 @@
 package datastore
 
 import (
+	"context"
+	"github.com/contiv/vpp/plugins/crd/api"
 	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"github.com/contiv/vpp/plugins/crd/testdata"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
 	"github.com/onsi/gomega"
+	"strings"
 	"testing"
+	"time"
 )
 
 //Checks adding a new node.
@@ -53,6 +58,52 @@ func TestVppDataStore_RetrieveNode(t *testing.T) {
 	gomega.Expect(nodeTwo).To(gomega.BeNil())
 }
 
+//Checks that modifying a copy of a node does not modify the node in the
+//data store.
+func TestVppDataStore_RetrieveNodeCopy(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	err := testdata.CreateNodeTestData(db)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	node, err := db.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	nodeCopy, err := db.RetrieveNodeCopy("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(nodeCopy).To(gomega.Equal(node))
+	gomega.Expect(nodeCopy).NotTo(gomega.BeIdenticalTo(node))
+
+	for _, ifc := range nodeCopy.NodeInterfaces {
+		if len(ifc.If.IPAddresses) > 0 {
+			ifc.If.IPAddresses[0] = "1.2.3.4/24"
+			break
+		}
+	}
+	nodeCopy.NodeInterfaces[100] = telemetrymodel.NodeInterface{}
+	for k := range nodeCopy.NodeBridgeDomains {
+		delete(nodeCopy.NodeBridgeDomains, k)
+	}
+	for k := range nodeCopy.NodeL2Fibs {
+		delete(nodeCopy.NodeL2Fibs, k)
+	}
+	nodeCopy.NodeIPArp[0].Ae.IPAddress = "1.2.3.4"
+	nodeCopy.NodeLiveness.LastUpdate++
+
+	origNode, err := db.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(origNode.NodeInterfaces).NotTo(gomega.HaveKey(100))
+	gomega.Expect(origNode.NodeBridgeDomains).NotTo(gomega.BeEmpty())
+	gomega.Expect(origNode.NodeL2Fibs).NotTo(gomega.BeEmpty())
+	gomega.Expect(origNode.NodeIPArp[0].Ae.IPAddress).NotTo(gomega.Equal("1.2.3.4"))
+	gomega.Expect(origNode.NodeLiveness.LastUpdate).NotTo(gomega.Equal(nodeCopy.NodeLiveness.LastUpdate))
+	for _, ifc := range origNode.NodeInterfaces {
+		gomega.Expect(ifc.If.IPAddresses).NotTo(gomega.ContainElement("1.2.3.4/24"))
+	}
+
+	_, err = db.RetrieveNodeCopy("NonExistentNode")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+}
+
 //Checks adding a node and then deleting it.
 //Checks whether expected error is returned when deleting non-existent key.
 func TestVppDataStore_DeleteNode(t *testing.T) {
@@ -228,13 +279,13 @@ func TestVppDataStore_SetNodeLiveness(t *testing.T) {
 	gomega.Expect(node.ID).To(gomega.Equal(uint32(1)))
 	gomega.Expect(node.ManIPAddr).To(gomega.Equal("10"))
 
-	nlive := telemetrymodel.NodeLiveness{"54321", "12345", 0, 0, 0, 0, ""}
+	nlive := telemetrymodel.NodeLiveness{BuildVersion: "54321", BuildDate: "12345"}
 	err := db.SetNodeLiveness("NENODE", &nlive)
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 	err = db.SetNodeLiveness("k8s_master", &nlive)
 	gomega.Expect(err).To(gomega.BeNil())
 
-	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(&telemetrymodel.NodeLiveness{"54321", "12345", 0, 0, 0, 0, ""}))
+	gomega.Expect(node.NodeLiveness).To(gomega.BeEquivalentTo(&telemetrymodel.NodeLiveness{BuildVersion: "54321", BuildDate: "12345"}))
 
 }
 
@@ -249,7 +300,7 @@ func TestVppDataStore_SetNodeTelemetry(t *testing.T) {
 	gomega.Expect(node.ID).To(gomega.Equal(uint32(1)))
 	gomega.Expect(node.ManIPAddr).To(gomega.Equal("10"))
 
-	ntele := telemetrymodel.NodeTelemetry{"d", []telemetrymodel.Output{}}
+	ntele := telemetrymodel.NodeTelemetry{Command: "d", Output: []telemetrymodel.Output{}}
 	nTeleMap := make(map[string]telemetrymodel.NodeTelemetry)
 	nTeleMap["k8s_master"] = ntele
 	err := db.SetNodeTelemetry("k8s_master", nTeleMap)
@@ -439,6 +490,43 @@ func TestVppDataStore_ClearCache(t *testing.T) {
 
 }
 
+func TestVppDataStore_ResetNodeData(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(db)).To(gomega.Succeed())
+	for _, node := range db.RetrieveAllNodes() {
+		gomega.Expect(db.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+
+	err := db.ResetNodeData("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+
+	node, err := db.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.ID).To(gomega.Equal(uint32(2)))
+	gomega.Expect(node.NodeLiveness).To(gomega.BeNil())
+	gomega.Expect(node.NodeInterfaces).To(gomega.BeNil())
+	gomega.Expect(node.NodeBridgeDomains).To(gomega.BeNil())
+	gomega.Expect(node.NodeL2Fibs).To(gomega.BeNil())
+	gomega.Expect(node.NodeIPArp).To(gomega.BeNil())
+
+	_, err = db.RetrieveNodeByHostIPAddr(node.ManIPAddr)
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	_, err = db.RetrieveNodeByGigEIPAddr(strings.Split(node.IPAddr, "/")[0])
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Data of other nodes is not touched
+	master, err := db.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(master.NodeLiveness).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(master.NodeInterfaces).To(gomega.Not(gomega.BeEmpty()))
+	_, err = db.RetrieveNodeByHostIPAddr(master.ManIPAddr)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	err = db.ResetNodeData("k8s-worker9")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+}
+
 func TestVppDataStore_ReinitializeCache(t *testing.T) {
 	gomega.RegisterTestingT(t)
 	db := NewVppDataStore()
@@ -500,3 +588,180 @@ func TestGetNodeLoopIFInfo(t *testing.T) {
 	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
 
 }
+
+//Checks that WaitForNode returns a node that is created while waiting.
+//Checks that WaitForNode returns the context error on timeout.
+func TestVppDataStore_WaitForNode(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	db.CreateNode(1, "k8s_master", "10", "10")
+	node, err := db.WaitForNode(context.Background(), "k8s_master")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.Name).To(gomega.Equal("k8s_master"))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		db.CreateNode(2, "k8s_worker1", "20", "20")
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	node, err = db.WaitForNode(ctx, "k8s_worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.Name).To(gomega.Equal("k8s_worker1"))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	node, err = db.WaitForNode(ctx, "NonExistentNode")
+	gomega.Expect(err).To(gomega.Equal(context.DeadlineExceeded))
+	gomega.Expect(node).To(gomega.BeNil())
+	gomega.Expect(db.nodeWaiters).To(gomega.BeEmpty())
+}
+
+//Checks looking up a node through each of the secondary indices.
+//Checks expected error for an unknown index name and a missing key.
+func TestVppDataStore_RetrieveNodeByIndex(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	db.CreateNode(1, "k8s_master", "192.168.16.1/24", "10.20.0.2")
+	node, err := db.RetrieveNode("k8s_master")
+	gomega.Expect(err).To(gomega.BeNil())
+	db.HostIPMap[node.ManIPAddr] = node
+	db.LoopIPMap["192.168.30.1/24"] = node
+	db.LoopMACMap["1a:2b:3c:4d:5e:01"] = node
+
+	lookups := map[string]string{
+		api.GigEIPIndex:  "192.168.16.1",
+		api.HostIPIndex:  "10.20.0.2",
+		api.ManIPIndex:   "10.20.0.2",
+		api.LoopIPIndex:  "192.168.30.1/24",
+		api.LoopMACIndex: "1a:2b:3c:4d:5e:01",
+	}
+	for indexName, key := range lookups {
+		n, err := db.RetrieveNodeByIndex(indexName, key)
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(n).To(gomega.Equal(node))
+	}
+
+	n, err := db.RetrieveNodeByIndex(api.GigEIPIndex, "1.2.3.4")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(n).To(gomega.BeNil())
+
+	n, err = db.RetrieveNodeByIndex("bogusIndex", "10.20.0.2")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(err.Error()).To(gomega.ContainSubstring("unknown node index"))
+	gomega.Expect(n).To(gomega.BeNil())
+}
+
+func TestVppDataStore_RetrieveNodeByAnyIP(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(db)).To(gomega.Succeed())
+
+	// BVI IP address, with or without the prefix length
+	for _, ip := range []string{"192.168.30.3", "192.168.30.3/24"} {
+		node, err := db.RetrieveNodeByAnyIP(ip)
+		gomega.Expect(err).To(gomega.BeNil())
+		gomega.Expect(node.Name).To(gomega.Equal("k8s-worker2"))
+	}
+
+	// Tap IP address present on all nodes
+	_, err := db.RetrieveNodeByAnyIP("10.2.1.2")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(err.Error()).To(gomega.ContainSubstring("k8s-master, k8s-worker1, k8s-worker2"))
+
+	// Tap /32 IP address unique to one node
+	worker1, err := db.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	for k, intf := range worker1.NodeInterfaces {
+		if intf.If.IfType == interfaces.InterfaceType_TAP_INTERFACE && len(intf.If.IPAddresses) > 0 &&
+			intf.If.IPAddresses[0] == "10.2.1.2/32" {
+			intf.If.IPAddresses = []string{"10.2.2.2/32"}
+			worker1.NodeInterfaces[k] = intf
+		}
+	}
+	node, err := db.RetrieveNodeByAnyIP("10.2.2.2")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node.Name).To(gomega.Equal("k8s-worker1"))
+
+	// Unknown IP address
+	node, err = db.RetrieveNodeByAnyIP("1.2.3.4")
+	gomega.Expect(err).NotTo(gomega.BeNil())
+	gomega.Expect(node).To(gomega.BeNil())
+}
+
+func TestVppDataStore_ValidateIndexIntegrity(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(db)).To(gomega.Succeed())
+	for _, node := range db.RetrieveAllNodes() {
+		gomega.Expect(db.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+	gomega.Expect(db.ValidateIndexIntegrity()).To(gomega.BeEmpty())
+
+	// Node deleted without cleaning up its secondary indices
+	delete(db.NodeMap, "k8s-worker1")
+	violations := db.ValidateIndexIntegrity()
+	gomega.Expect(violations).To(gomega.HaveLen(4))
+	for _, v := range violations {
+		gomega.Expect(v).To(gomega.ContainSubstring("points to deleted node k8s-worker1"))
+	}
+
+	// Node missing from a secondary index
+	db = NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(db)).To(gomega.Succeed())
+	for _, node := range db.RetrieveAllNodes() {
+		gomega.Expect(db.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+	delete(db.HostIPMap, "10.20.0.2")
+	gomega.Expect(db.ValidateIndexIntegrity()).To(gomega.Equal([]string{
+		"node k8s-master not found in host index under key 10.20.0.2",
+	}))
+}
+
+func TestVppDataStore_RebuildIndexes(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	db := NewVppDataStore()
+	gomega.Expect(testdata.CreateNodeTestData(db)).To(gomega.Succeed())
+	for _, node := range db.RetrieveAllNodes() {
+		gomega.Expect(db.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+	}
+
+	// Corrupt the indices
+	master, err := db.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	loopIF, err := GetNodeLoopIFInfo(master)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	delete(db.HostIPMap, master.ManIPAddr)
+	delete(db.LoopMACMap, loopIF.If.PhysAddress)
+	db.GigEIPMap["10.99.0.1"] = &telemetrymodel.Node{Name: "k8s-stale"}
+	gomega.Expect(db.ValidateIndexIntegrity()).To(gomega.HaveLen(3))
+
+	gomega.Expect(db.RebuildIndexes()).To(gomega.BeEmpty())
+	gomega.Expect(db.ValidateIndexIntegrity()).To(gomega.BeEmpty())
+
+	node, err := db.RetrieveNodeByHostIPAddr(master.ManIPAddr)
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node).To(gomega.BeIdenticalTo(master))
+	node, err = db.RetrieveNodeByLoopMacAddr(loopIF.If.PhysAddress)
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(node).To(gomega.BeIdenticalTo(master))
+	_, err = db.RetrieveNodeByGigEIPAddr("10.99.0.1")
+	gomega.Expect(err).To(gomega.Not(gomega.BeNil()))
+	gomega.Expect(db.GigEIPMap).To(gomega.HaveLen(3))
+	gomega.Expect(db.HostIPMap).To(gomega.HaveLen(3))
+
+	// Nodes without collected interfaces are only in the GigE IP index
+	gomega.Expect(db.ResetNodeData("k8s-worker1")).To(gomega.Succeed())
+	gomega.Expect(db.RebuildIndexes()).To(gomega.BeEmpty())
+	gomega.Expect(db.GigEIPMap).To(gomega.HaveLen(3))
+	gomega.Expect(db.HostIPMap).To(gomega.HaveLen(2))
+
+	// Conflicting addresses are reported
+	worker2, err := db.RetrieveNode("k8s-worker2")
+	gomega.Expect(err).To(gomega.BeNil())
+	worker2.ManIPAddr = master.ManIPAddr
+	gomega.Expect(db.RebuildIndexes()).To(gomega.Equal([]string{
+		"duplicate Host IP Address 10.20.0.2, hosts k8s-master, k8s-worker2",
+	}))
+}
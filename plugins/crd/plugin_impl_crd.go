@@ -43,6 +43,12 @@ import (
 	apiextcs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 )
 
+const (
+	// reportHistorySize is the number of validation reports kept in the
+	// report history
+	reportHistorySize = 10
+)
+
 // Plugin watches configuration of K8s resources (as reflected by KSR into ETCD)
 // for changes in policies, pods and namespaces and applies rules into extendable
 // set of network stacks.
@@ -127,7 +133,7 @@ func (p *Plugin) Init() error {
 		Synced:   false,
 		VppCache: datastore.NewVppDataStore(),
 		K8sCache: datastore.NewK8sDataStore(),
-		Report:   datastore.NewSimpleReport(p.Log.NewLogger("-report")),
+		Report:   datastore.NewSimpleReport(p.Log.NewLogger("-report"), reportHistorySize),
 	}
 	p.cache.Log.SetLevel(logging.DebugLevel)
 	p.cache.Init()
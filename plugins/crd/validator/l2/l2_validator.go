@@ -23,10 +23,72 @@ import (
 	nodemodel "github.com/contiv/vpp/plugins/ksr/model/node"
 	"github.com/ligato/cn-infra/logging"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
-	"strconv"
+	"net"
+	"regexp"
+	"sort"
 	"strings"
 )
 
+const (
+	// numBridgeDomains is the number of bridge domains on a standard Contiv
+	// node (the Vxlan BD)
+	numBridgeDomains = 1
+
+	// defaultVxlanOverhead is the number of bytes added to each overlay
+	// packet by the VXLAN encapsulation (outer Ethernet, IP, UDP and VXLAN
+	// headers)
+	defaultVxlanOverhead = 50
+
+	// maxLabelKeyTypoDistance is the maximum edit distance between a pod
+	// label key and a missing required key for the label key to be reported
+	// as a likely typo
+	maxLabelKeyTypoDistance = 2
+
+	// defaultVxlanBDName is the name of the overlay bridge domain on each
+	// node, unless overridden in a validation
+	defaultVxlanBDName = "vxlanBD"
+
+	// etcdPodLabelKey and etcdPodLabelValue identify the contiv-etcd pod
+	etcdPodLabelKey   = "k8s-app"
+	etcdPodLabelValue = "contiv-etcd"
+
+	// defaultSplitHorizonGroup is the split-horizon group of VXLAN tunnel
+	// interfaces in a bridge domain
+	defaultSplitHorizonGroup = 1
+
+	// hostTapTag is the tag of the tap interface interconnecting VPP with
+	// the host stack on each node
+	hostTapTag = "tap-vpp2"
+
+	// appLabelKey is the pod label key identifying the application (e.g.
+	// the DaemonSet) a pod belongs to
+	appLabelKey = "k8s-app"
+
+	// dnsPodLabelValue is the app label value of the kube-dns pods
+	dnsPodLabelValue = "kube-dns"
+
+	// livenessStateOK is the liveness state reported by a running agent
+	livenessStateOK = 1
+
+	// minIPMtu is the minimum MTU of a link carrying IPv4 packets
+	minIPMtu = 68
+
+	// maxHardwareMtu is the maximum MTU supported by the network hardware
+	maxHardwareMtu = 9216
+
+	// numGlobalInvariantMessages is the number of global messages emitted
+	// by ValidateGlobalInvariants
+	numGlobalInvariantMessages = 3
+)
+
+// daemonSetRevisionLabels lists the pod labels whose values must be the
+// same for all pods of a DaemonSet
+var daemonSetRevisionLabels = []string{"pod-template-generation", "controller-revision-hash"}
+
+// defaultStaticFibBDs lists the bridge domains whose L2FIB entries must all
+// be statically configured, unless overridden in the Validator
+var defaultStaticFibBDs = []string{defaultVxlanBDName}
+
 // Validator is the implementation of the ContivTelemetryProcessor interface.
 type Validator struct {
 	Log logging.Logger
@@ -34,30 +96,85 @@ type Validator struct {
 	VppCache api.VppCache
 	K8sCache api.K8sCache
 	Report   api.Report
+
+	// VxlanOverhead is the encapsulation overhead used when validating the
+	// MTU headroom of underlay interfaces. defaultVxlanOverhead is used
+	// when not set.
+	VxlanOverhead uint32
+
+	// BviIPEncodesNodeID enables the validation of the convention where the
+	// host octet of each node's vxlanBVI IP address is the node's ID.
+	BviIPEncodesNodeID bool
+
+	// StaticFibBDs lists the names of bridge domains in which all L2FIB
+	// entries must be static. defaultStaticFibBDs is used when not set.
+	StaticFibBDs []string
+
+	// SplitHorizonGroup is the split-horizon group expected on all VXLAN
+	// tunnel interfaces in a bridge domain. defaultSplitHorizonGroup is used
+	// when not set.
+	SplitHorizonGroup uint32
+
+	// VNI is the VNI expected on all VXLAN tunnel interfaces. api.VppVNI is
+	// used when not set.
+	VNI uint32
+
+	// ExpectedExtraInterfaces lists the names of interfaces that are not
+	// part of the standard Contiv dataplane but are legitimately present
+	// (e.g. management or monitoring interfaces). Each entry matches an
+	// interface by its exact name or as a regular expression matching the
+	// whole name. Matching interfaces are exempt from "unexpected interface"
+	// reports.
+	ExpectedExtraInterfaces []string
+
+	// Workers is the number of nodes validated concurrently by the checks
+	// that validate each node independently of the other nodes. Nodes are
+	// validated one at a time when not set.
+	Workers int
+
+	// results holds the results of the validations performed since the
+	// last call to Validate()
+	results []api.ValidationResult
 }
 
 // Validate performes the validation of L2 telemetry data collected from a
-// Contiv cluster.
+// Contiv cluster. The checks that validate each node independently of the
+// other nodes are performed first, with up to Workers nodes validated
+// concurrently. The cross-node validations and the global invariants are
+// performed when all nodes have been validated.
 func (v *Validator) Validate() {
-	v.ValidateArpTables()
-	v.ValidateBridgeDomains()
-	v.ValidateL2FibEntries()
+	v.results = nil
+	v.runNodeChecks(v.nodeChecks(v.VppCache.RetrieveAllNodes())...)
 	v.ValidateK8sNodeInfo()
-	v.ValidatePodInfo()
+	v.ValidatePodHostBinding()
+	v.ValidateGigEIpUniqueness()
+	v.ValidateDnsPodReachability()
+	v.ValidateTapVersionUniformity()
+	v.ValidatePodGatewayCollision()
+	v.ValidatePodCidrDisjointness()
+	v.ValidateUnderlaySubnetUniformity()
+	v.ValidateCollectedVsKnownNodes()
+	v.ValidateGlobalInvariants()
 }
 
 // ValidateArpTables validates the the entries of node ARP tables to
-// make sure that the number of entries is correct as well as making sure
-// that each entry's ip address and mac address correspond to the correct
-// node in the network.
+// make sure that each node has an entry for the BVI of every other node as
+// well as making sure that each entry's ip address and mac address
+// correspond to the correct node in the network.
 func (v *Validator) ValidateArpTables() {
+	v.runNodeChecks(arpTablesCheck(v.VppCache.RetrieveAllNodes()))
+}
+
+// checkArpTables performs ValidateArpTables for the nodes in nodeList;
+// clusterNodes are all nodes in the cluster.
+func (v *Validator) checkArpTables(clusterNodes []*telemetrymodel.Node, nodeList []*telemetrymodel.Node,
+	report *reportBuffer) int {
 	errCnt := 0
-	nodeList := v.VppCache.RetrieveAllNodes()
 
 	for _, node := range nodeList {
 
 		loopNodeMap := make(map[string]bool)
-		for _, n := range nodeList {
+		for _, n := range clusterNodes {
 			if n.Name != node.Name {
 				loopNodeMap[n.Name] = true
 			}
@@ -72,7 +189,7 @@ func (v *Validator) ValidateArpTables() {
 			if !ok {
 				errString := fmt.Sprintf("invalid ARP entry <'%s'-'%s'>: bad ifIndex %d",
 					arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress, arpTableEntry.AeMeta.IfIndex)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
 				errCnt++
 				continue
 			}
@@ -87,16 +204,16 @@ func (v *Validator) ValidateArpTables() {
 			if err != nil {
 				errString := fmt.Sprintf("invalid ARP entry <'%s'-'%s'>: bad MAC Addess",
 					arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
 				addressNotFound = true
 				errCnt++
 			}
 
-			ipNode, err := v.VppCache.RetrieveNodeByLoopIPAddr(arpTableEntry.Ae.IPAddress + "/24")
+			ipNode, err := v.VppCache.RetrieveNodeByLoopIPAddr(arpTableEntry.Ae.IPAddress + api.SubnetMask)
 			if err != nil {
 				errString := fmt.Sprintf("invalid ARP entry <'%s'-'%s'>: bad IP Addess",
 					arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
 				addressNotFound = true
 				errCnt++
 			}
@@ -108,7 +225,10 @@ func (v *Validator) ValidateArpTables() {
 			if macNode.Name != ipNode.Name {
 				errString := fmt.Sprintf("invalid ARP entry <'%s'-'%s'>: MAC -> node %s, IP -> node %s",
 					arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress, macNode.Name, ipNode.Name)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				if loopIf, err := datastore.GetNodeLoopIFInfo(ipNode); err == nil {
+					errString += fmt.Sprintf(", whose BVI MAC address is '%s'", loopIf.If.PhysAddress)
+				}
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
 				errCnt++
 			}
 
@@ -117,21 +237,27 @@ func (v *Validator) ValidateArpTables() {
 
 		for nodeName := range loopNodeMap {
 			errCnt++
-			errString := fmt.Sprintf("missing ARP entry for node %s", nodeName)
-			v.Report.AppendToNodeReport(node.Name, errString)
+			errString := fmt.Sprintf("node %s missing ARP for node %s's BVI", node.Name, nodeName)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
 		}
 	}
 
-	v.addSummary(errCnt, "IP ARP")
+	return errCnt
 }
 
-//ValidateBridgeDomains makes sure that each node in the cache has the right
+// ValidateBridgeDomains makes sure that each node in the cache has the right
 // number of vxlan_tunnels for the number of nodes as well as checking that
 // each vxlan_tunnel points to a node that has a corresponding but opposite
 // tunnel itself.
 func (v *Validator) ValidateBridgeDomains() {
+	v.runNodeChecks(bridgeDomainsCheck(v.VppCache.RetrieveAllNodes()))
+}
+
+// checkBridgeDomains performs ValidateBridgeDomains for the nodes in
+// nodeList; clusterNodes are all nodes in the cluster.
+func (v *Validator) checkBridgeDomains(clusterNodes []*telemetrymodel.Node, nodeList []*telemetrymodel.Node,
+	report *reportBuffer) int {
 	errCnt := 0
-	nodeList := v.VppCache.RetrieveAllNodes()
 
 	nodeMap := make(map[string]bool)
 	for _, node := range nodeList {
@@ -141,7 +267,7 @@ func (v *Validator) ValidateBridgeDomains() {
 validateNodeBD:
 	for _, node := range nodeList {
 		nodeVxlanMap := make(map[string]bool)
-		for _, n := range nodeList {
+		for _, n := range clusterNodes {
 			nodeVxlanMap[n.Name] = true
 		}
 
@@ -153,7 +279,7 @@ validateNodeBD:
 				if vxLanBD != nil {
 					errString := fmt.Sprintf("multiple vxlanBD bridge domains - skipping L2 validation")
 					errCnt++
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue validateNodeBD
 				}
 				vxLanBD = &bdomain
@@ -163,7 +289,7 @@ validateNodeBD:
 		if vxLanBD == nil {
 			errCnt++
 			errString := fmt.Sprintf("no vxlan BD - skipping L2 validation")
-			v.Report.AppendToNodeReport(node.Name, errString)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 			continue
 		}
 
@@ -184,7 +310,7 @@ validateNodeBD:
 			if !ok {
 				errCnt++
 				errString := fmt.Sprintf("ifIndex %d invalid for BD interface %s", ifIndex, bdIfc.Name)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 				continue
 			}
 
@@ -194,7 +320,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("duplicate BVI, type %+v, BVI %s (ifIndex %d, ifName %s)",
 						nodeIfc.If.IfType, bdIfc.Name, ifIndex, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 				}
 
 				// BVI must be a software loopback interface
@@ -202,7 +328,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("invalid BVI type %+v, BVI %s (ifIndex %d, ifName %s)",
 						nodeIfc.If.IfType, bdIfc.Name, ifIndex, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				}
 
@@ -214,7 +340,7 @@ validateNodeBD:
 					errString := fmt.Sprintf("validator internal error: bad MAC Addr index, "+
 						"MAC Addr %s, BVI %s (ifIndex %d, ifName %s)",
 						nodeIfc.If.PhysAddress, bdIfc.Name, ifIndex, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				} else {
 					delete(nodeVxlanMap, n.Name)
@@ -225,7 +351,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("invalid BD interface type %+v, BVI %s (ifIndex %d, ifName %s)",
 						nodeIfc.If.IfType, bdIfc.Name, ifIndex, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				}
 
@@ -237,7 +363,7 @@ validateNodeBD:
 						node.NodeInterfaces[int(ifIndex)].IfMeta.VppInternalName,
 						node.NodeInterfaces[int(ifIndex)].If.Vxlan.Vni,
 						api.VppVNI)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 				}
 
 				// Make sure the VXLAN's tunnel source IP address points to the current node.
@@ -246,7 +372,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("error finding node with src IP %s",
 						nodeIfc.If.Vxlan.SrcAddress)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				}
 
@@ -255,7 +381,7 @@ validateNodeBD:
 					errString := fmt.Sprintf("vxlan_tunnel %s has source ip %s which points "+
 						"to a different node than %s.",
 						nodeIfc.If.Name, nodeIfc.If.Vxlan.SrcAddress, node.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				}
 
@@ -267,7 +393,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("node with dst ip %s in vxlan_tunnel %s not found",
 						nodeIfc.If.Vxlan.DstAddress, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 					continue
 				}
 
@@ -284,7 +410,7 @@ validateNodeBD:
 					errCnt++
 					errString := fmt.Sprintf("no matching vxlan_tunnel found on remote node %s for vxlan %s",
 						dstipNode.Name, nodeIfc.If.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 				}
 				i++
 
@@ -293,7 +419,7 @@ validateNodeBD:
 					delete(nodeVxlanMap, n1.Name)
 				} else {
 					errCnt++
-					v.Report.LogErrAndAppendToNodeReport(n1.Name,
+					report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain,
 						fmt.Sprintf("validator internal error: inconsistent GigE Address index, dest addr %s",
 							dstAddr))
 				}
@@ -301,24 +427,24 @@ validateNodeBD:
 		}
 
 		//checks if there are an unequal amount vxlan tunnels for the current node versus the total number of nodes
-		if i != len(nodeList) {
+		if i != len(clusterNodes) {
 			errCnt++
 			errString := fmt.Sprintf("the number of valid BD interfaces does not match the number of nodes "+
-				"in cluster: got %d, expected %d", i, len(nodeList))
-			v.Report.AppendToNodeReport(node.Name, errString)
+				"in cluster: got %d, expected %d", i, len(clusterNodes))
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 		}
 
 		if !hasBviIfc {
 			errCnt++
 			errString := fmt.Sprintf("BVI in the Contiv cluster Vxlan BD is invalid or missing")
-			v.Report.AppendToNodeReport(node.Name, errString)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 			continue
 		}
 		if len(nodeVxlanMap) > 0 {
 			for n := range nodeVxlanMap {
 				errCnt++
 				errString := fmt.Sprintf("BD interface missing or invalid for node %s", n)
-				v.Report.AppendToNodeReport(node.Name, errString)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 			}
 			continue
 		}
@@ -327,20 +453,27 @@ validateNodeBD:
 	}
 
 	//make sure that each node has been successfully validated
-	if len(nodeMap) > 0 {
-		for nodeName := range nodeMap {
-			v.Report.AppendToNodeReport(nodeName, fmt.Sprintf("failed to validate the Contiv cluster Vxlan BD"))
+	for _, node := range nodeList {
+		if nodeMap[node.Name] {
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain,
+				fmt.Sprintf("failed to validate the Contiv cluster Vxlan BD"))
 		}
 	}
 
-	v.addSummary(errCnt, "BD")
+	return errCnt
 }
 
 // ValidateL2FibEntries will validate that each nodes fib entries ip address
 // point to the right loop interface and the mac addresses match
 func (v *Validator) ValidateL2FibEntries() {
+	v.runNodeChecks(l2FibEntriesCheck(v.VppCache.RetrieveAllNodes()))
+}
+
+// checkL2FibEntries performs ValidateL2FibEntries for the nodes in
+// nodeList; clusterNodes are all nodes in the cluster.
+func (v *Validator) checkL2FibEntries(clusterNodes []*telemetrymodel.Node, nodeList []*telemetrymodel.Node,
+	report *reportBuffer) int {
 	errCnt := 0
-	nodeList := v.VppCache.RetrieveAllNodes()
 
 	for _, node := range nodeList {
 		fibHasLoopIF := false
@@ -348,13 +481,13 @@ func (v *Validator) ValidateL2FibEntries() {
 		if err != nil {
 			errCnt++
 			errString := fmt.Sprintf("%s - skipping L2Fib validation for node %s", err.Error(), node.Name)
-			v.Report.AppendToNodeReport(node.Name, errString)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 			continue
 		}
 
 		// Used to mark all nodes for which there exists an L2Fib entry
 		nodeFibMap := make(map[string]bool)
-		for _, n := range nodeList {
+		for _, n := range clusterNodes {
 			nodeFibMap[n.Name] = true
 		}
 
@@ -391,7 +524,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("invalid L2Fib BVI entry '%s': loop interface not found on node %s",
 						feKey, node.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 				} else {
 					// check if the L2Fib entry's MAC address is the same as
 					// in the BVI interface on the local node
@@ -399,7 +532,7 @@ func (v *Validator) ValidateL2FibEntries() {
 						errCnt++
 						errString := fmt.Sprintf("L2Fib BVI entry '%s' invalid - bad MAC address; "+
 							"have '%s', expecting '%s'", feKey, feVal.Fe.PhysAddress, loopIf.If.PhysAddress)
-						v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+						report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 					}
 				}
 
@@ -411,7 +544,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("L2Fib validator internal error: "+
 						"inconsistent MAC Address index, MAC %s", feVal.Fe.PhysAddress)
-					v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+					report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 				}
 
 				delete(fibNodeMap, feKey)
@@ -425,7 +558,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("outgoing interface for L2Fib entry '%s' not found ifName %s, "+
 						"ifIndex %d", feVal.Fe.PhysAddress, feVal.Fe.OutgoingIfName, feVal.FeMeta.OutgoingIfIndex)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 					continue
 				}
 
@@ -435,7 +568,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("invalid L2Fib entry '%s': "+
 						"remote node for VXLAN DstIP '%s' not found", feKey, intf.If.Vxlan.DstAddress)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 					continue
 				}
 
@@ -447,7 +580,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("invalid L2Fib entry '%s': missing loop interface on remote node %s",
 						feVal.Fe.PhysAddress, macNode.Name)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 					continue
 				}
 
@@ -457,7 +590,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("invalid L2Fib entry '%s': have MAC Address '%s', expecting %s",
 						feKey, feVal.Fe.PhysAddress, remoteLoopIF.If.PhysAddress)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 				}
 
 				// Do a consistency check of internal databases and report
@@ -466,7 +599,7 @@ func (v *Validator) ValidateL2FibEntries() {
 					errCnt++
 					errString := fmt.Sprintf("L2Fib validator internal error: "+
 						"inconsistent MAC Address index, MAC %s", feVal.Fe.PhysAddress)
-					v.Report.AppendToNodeReport(node.Name, errString)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 				}
 
 				delete(fibNodeMap, feKey)
@@ -477,25 +610,25 @@ func (v *Validator) ValidateL2FibEntries() {
 		if !fibHasLoopIF {
 			errCnt++
 			errString := fmt.Sprintf("L2Fib entry for the 'loop0' interface not found")
-			v.Report.AppendToNodeReport(node.Name, errString)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 		}
 
 		// Show all nodes for which there is no L2FIB entry
 		for remoteNodeName := range nodeFibMap {
 			errCnt++
 			errString := fmt.Sprintf("missing L2Fib entry for node %s", remoteNodeName)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 		}
 
 		// Show all L2Fib entrie for which there is no node
 		for fibEntry := range fibNodeMap {
 			errCnt++
 			errString := fmt.Sprintf("dangling L2Fib entry %s - no node for entry found", fibEntry)
-			v.Report.AppendToNodeReport(node.Name, errString)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
 		}
 	}
 
-	v.addSummary(errCnt, "L2Fib")
+	return errCnt
 }
 
 // ValidateK8sNodeInfo will make sure that K8s's view of nodes in the cluster
@@ -520,7 +653,7 @@ func (v *Validator) ValidateK8sNodeInfo() {
 		if err != nil {
 			errCnt++
 			errString := fmt.Sprintf("node with name %s not present in the k8s node map", node.Name)
-			v.Report.AppendToNodeReport(node.Name, errString)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, errString)
 			continue
 		}
 
@@ -533,14 +666,16 @@ func (v *Validator) ValidateK8sNodeInfo() {
 	if len(k8sNodeMap) > 0 {
 		errCnt++
 		for k8sNode := range k8sNodeMap {
-			v.Report.AppendToNodeReport(k8sNode, fmt.Sprintf("Contiv node missing for K8s node %s", k8sNode))
+			v.Report.AppendToNodeReportWithCategory(k8sNode, api.CategoryNodes,
+				fmt.Sprintf("Contiv node missing for K8s node %s", k8sNode))
 		}
 	}
 
 	if len(nodeMap) > 0 {
 		errCnt++
 		for contivNode := range nodeMap {
-			v.Report.AppendToNodeReport(contivNode, fmt.Sprintf("K8s node missing for Contiv node %s", contivNode))
+			v.Report.AppendToNodeReportWithCategory(contivNode, api.CategoryNodes,
+				fmt.Sprintf("K8s node missing for Contiv node %s", contivNode))
 		}
 	}
 
@@ -549,230 +684,2475 @@ func (v *Validator) ValidateK8sNodeInfo() {
 
 // ValidatePodInfo will check  that each pod has a valid host ip address node
 // and that the information correctly correlates between the nodes and the pods.
+// Pods bound to an unknown host IP address are reported by
+// ValidatePodHostBinding and skipped here.
 func (v *Validator) ValidatePodInfo() {
+	v.runNodeChecks(podInfoCheck)
+}
+
+// checkPodInfo performs ValidatePodInfo for the nodes in nodeList. The pods
+// of a node are the pods whose host IP address resolves to the node.
+func (v *Validator) checkPodInfo(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
 	errCnt := 0
-	podList := v.K8sCache.RetrieveAllPods()
 
-	tapMap := make(map[string]map[uint32]telemetrymodel.NodeInterface, 0)
-	for _, node := range v.VppCache.RetrieveAllNodes() {
-		podIfIPCidrParts := strings.Split(node.NodeIPam.Config.PodIfIPCIDR, "/")
-		podIfMaskLen, err := strconv.Atoi(podIfIPCidrParts[1])
-		if err != nil {
+	for _, vppNode := range nodeList {
+		tapMap := make(map[uint32]telemetrymodel.NodeInterface)
+		if _, podIfNet, err := net.ParseCIDR(vppNode.NodeIPam.Config.PodIfIPCIDR); err != nil {
 			errCnt++
-			errString := fmt.Sprintf("invalid IPAM PodIfIPCIDR %s", node.NodeIPam.Config.PodIfIPCIDR)
-			v.Report.AppendToNodeReport(node.Name, errString)
-			continue
+			errString := fmt.Sprintf("invalid IPAM PodIfIPCIDR %s", vppNode.NodeIPam.Config.PodIfIPCIDR)
+			report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+		} else {
+			for _, intf := range vppNode.NodeInterfaces {
+				if strings.Contains(intf.IfMeta.VppInternalName, "tap") {
+					for _, ip := range intf.If.IPAddresses {
+						tapIP, _, err := net.ParseCIDR(ip)
+						if err != nil || !podIfNet.Contains(tapIP) {
+							continue
+						}
+						tapMap[intf.IfMeta.SwIfIndex] = intf
+					}
+				}
+			}
+		}
+
+		podList := make([]*telemetrymodel.Pod, 0)
+		if hostNode, err := v.VppCache.RetrieveNodeByHostIPAddr(vppNode.ManIPAddr); err == nil && hostNode == vppNode {
+			podList = v.K8sCache.RetrievePodsByHostIPAddr(vppNode.ManIPAddr)
 		}
 
-		podIfIPMask := maskLength2Mask(podIfMaskLen)
-		podIfIPPfx := ip2uint32(podIfIPCidrParts[0]) &^ podIfIPMask
+		podMap := make(map[string]bool)
 
-		for _, intf := range node.NodeInterfaces {
-			if strings.Contains(intf.IfMeta.VppInternalName, "tap") {
-				for _, ip := range intf.If.IPAddresses {
-					ipAddr := strings.Split(ip, "/")
-					tapIP := ip2uint32(ipAddr[0])
+		for _, pod := range podList {
+			podPtr, ok := vppNode.PodMap[pod.Name]
+			if !ok {
+				errCnt++
+				report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods,
+					fmt.Sprintf("pod %s's IP address (%s) points to node %s, "+
+						"but pod is not present in node's podMap", pod.Name, pod.HostIPAddress, vppNode.Name))
+				continue
+			}
 
-					if tapIP&^podIfIPMask != podIfIPPfx {
-						continue
+			if pod != podPtr {
+				errCnt++
+				errString := fmt.Sprintf("pod %s in node's podMap (%+v) is not the same as "+
+					"the pod in k8s cache (%+v)", podPtr.Name, podPtr, pod)
+				report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+				continue
+			}
+
+			k8sNode, err := v.K8sCache.RetrieveK8sNode(vppNode.Name)
+			if err != nil {
+				errCnt++
+				errString := fmt.Sprintf("vppNode '%s' hosting pod '%s' not in K8s database",
+					vppNode.Name, pod.Name)
+				report.LogErrAndAppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+				continue
+			}
+
+			// Make sure that K8s view of the Pod's host IP address and host name
+			// are consistent with Contiv's view
+			for _, adr := range k8sNode.Addresses {
+				switch adr.Type {
+				case nodemodel.NodeAddress_NodeInternalIP:
+					if adr.Address != pod.HostIPAddress {
+						errCnt++
+						errString := fmt.Sprintf("pod %s: Host IP Addr '%s' does not match NodeInternalIP "+
+							"'%s' in K8s database", pod.Name, pod.HostIPAddress, adr.Address)
+						report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+					}
+				case nodemodel.NodeAddress_NodeHostName:
+					if adr.Address != vppNode.Name {
+						errCnt++
+						errString := fmt.Sprintf("pod %s: Node name %s does not match NodeHostName %s"+
+							"in K8s database", pod.Name, vppNode.Name, adr.Address)
+						report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
 					}
-					tapMap[node.Name][intf.IfMeta.SwIfIndex] = intf
+				default:
+					errCnt++
+					errString := fmt.Sprintf("pod %s: unknown address type %+v", pod.Name, adr)
+					report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
 				}
 			}
-		}
-	}
 
-	podMap := make(map[string]string)
+			// Skip over host-network pods
+			if pod.IPAddress == pod.HostIPAddress {
+				continue
+			}
+
+			_, k8sPodNet, err := net.ParseCIDR(k8sNode.Pod_CIDR)
+			if err != nil {
+				errCnt++
+				errString := fmt.Sprintf("invalid Pod_CIDR %s", k8sNode.Pod_CIDR)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
 
-	for _, pod := range podList {
-		// Check if we have a vppNode with the Host IP address that K8s specifies
-		// for the Pod
-		vppNode, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress)
-		if err != nil {
-			errCnt++
-			errString := fmt.Sprintf("vppNode not found for Pod %s with Host IP %s - skipping Pod validation",
-				pod.Name, pod.HostIPAddress)
-			v.Report.AppendToNodeReport(api.GlobalMsg, errString)
-			continue
-		}
+			_, podIfNet, err := net.ParseCIDR(vppNode.NodeIPam.Config.PodIfIPCIDR)
+			if err != nil {
+				errCnt++
+				errString := fmt.Sprintf("invalid IPAM PodIfIPCIDR %s", vppNode.NodeIPam.Config.PodIfIPCIDR)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
 
-		podPtr, ok := vppNode.PodMap[pod.Name]
-		if !ok {
-			errCnt++
-			v.Report.AppendToNodeReport(vppNode.Name, fmt.Sprintf("pod %s's IP address (%s) points to node %s, "+
-				"but pod is not present in node's podMap", pod.Name, pod.HostIPAddress, vppNode.Name))
-			continue
+			k8sMaskLen, k8sBits := k8sPodNet.Mask.Size()
+			podIfMaskLen, podIfBits := podIfNet.Mask.Size()
+			if k8sBits != podIfBits {
+				errCnt++
+				errString := fmt.Sprintf("IP address family mismatch: K8s Pod CIDR: %s, Contiv PodIfIpCIDR %s",
+					k8sNode.Pod_CIDR, vppNode.NodeIPam.Config.PodIfIPCIDR)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
+			if k8sMaskLen != podIfMaskLen {
+				errCnt++
+				errString := fmt.Sprintf("IP address mask mismatch: K8s Pod CIDR: %s, Contiv PodIfIpCIDR %s",
+					k8sNode.Pod_CIDR, vppNode.NodeIPam.Config.PodIfIPCIDR)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
+
+			podIP := net.ParseIP(pod.IPAddress)
+			if podIP == nil {
+				errCnt++
+				errString := fmt.Sprintf("pod %s: invalid IP address '%s'", pod.Name, pod.IPAddress)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
+			if ipFamilyMismatch(podIP, k8sPodNet) {
+				errCnt++
+				errString := fmt.Sprintf("pod %s: IP address family mismatch: %s address %s, %s Pod CIDR %s",
+					pod.Name, ipFamily(podIP), pod.IPAddress, netFamily(k8sPodNet), k8sNode.Pod_CIDR)
+				report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+				continue
+			}
+
+			// Populate Pod's VPP interface data (IP addresses, interface name and
+			// ifIndex)
+			podMap[pod.Name] = true
+			podHost := hostBits(podIP, k8sPodNet)
+
+			for _, intf := range vppNode.NodeInterfaces {
+				if strings.Contains(intf.IfMeta.VppInternalName, "tap") {
+					for _, ip := range intf.If.IPAddresses {
+
+						tapIP, _, err := net.ParseCIDR(ip)
+						if err != nil || !podIfNet.Contains(tapIP) {
+							continue
+						}
+
+						if podHost.Equal(hostBits(tapIP, podIfNet)) {
+							pod.VppIfIPAddr = ip
+							pod.VppIfInternalName = intf.IfMeta.VppInternalName
+							pod.VppIfName = intf.If.Name
+							pod.VppSwIfIdx = intf.IfMeta.SwIfIndex
+							delete(podMap, pod.Name)
+							delete(tapMap, intf.IfMeta.SwIfIndex)
+						}
+					}
+				}
+			}
 		}
 
-		if pod != podPtr {
+		for podName := range podMap {
 			errCnt++
-			errString := fmt.Sprintf("pod %s in node's podMap (%+v) is not the same as "+
-				"the pod in k8s cache (%+v)", podPtr.Name, podPtr, pod)
-			v.Report.AppendToNodeReport(vppNode.Name, errString)
-			continue
+			errString := fmt.Sprintf("no valid VPP tap interface found for pod %s", podName)
+			report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
 		}
 
-		k8sNode, err := v.K8sCache.RetrieveK8sNode(vppNode.Name)
-		if err != nil {
+		for ifIdx, intf := range tapMap {
+			if v.isExpectedExtraInterface(intf.If.Name) {
+				continue
+			}
 			errCnt++
-			errString := fmt.Sprintf("vppNode '%s' hosting pod '%s' not in K8s database",
-				vppNode.Name, pod.Name)
-			v.Report.LogErrAndAppendToNodeReport(vppNode.Name, errString)
-			continue
+			errString := fmt.Sprintf("dangling pod-facing tap interface '%s' (vppName '%s', ifIndex %d)",
+				intf.If.Name, intf.IfMeta.VppInternalName, ifIdx)
+			report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
 		}
+	}
 
-		// Make sure that K8s view of the Pod's host IP address and host name
-		// are consistent with Contiv's view
-		for _, adr := range k8sNode.Addresses {
-			switch adr.Type {
-			case nodemodel.NodeAddress_NodeInternalIP:
-				if adr.Address != pod.HostIPAddress {
-					errCnt++
-					errString := fmt.Sprintf("pod %s: Host IP Addr '%s' does not match NodeInternalIP "+
-						"'%s' in K8s database", pod.Name, pod.HostIPAddress, adr.Address)
-					v.Report.AppendToNodeReport(vppNode.Name, errString)
-				}
-			case nodemodel.NodeAddress_NodeHostName:
-				if adr.Address != vppNode.Name {
+	return errCnt
+}
+
+// ValidateIPAddressFormat makes sure that all IP addresses collected from
+// node agents are well-formed: interface IP addresses must be valid CIDR
+// strings, ARP and VXLAN tunnel addresses must be valid IP addresses.
+func (v *Validator) ValidateIPAddressFormat() {
+	v.runNodeChecks(ipAddressFormatCheck)
+}
+
+// checkIPAddressFormat performs ValidateIPAddressFormat for the nodes in nodeList.
+func (v *Validator) checkIPAddressFormat(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			for _, ip := range intf.If.IPAddresses {
+				if _, _, err := net.ParseCIDR(ip); err != nil {
 					errCnt++
-					errString := fmt.Sprintf("pod %s: Node name %s does not match NodeHostName %s"+
-						"in K8s database", pod.Name, vppNode.Name, adr.Address)
-					v.Report.AppendToNodeReport(vppNode.Name, errString)
+					errString := fmt.Sprintf("malformed IP address '%s' on interface %s (ifIndex %d)",
+						ip, intf.If.Name, intf.IfMeta.SwIfIndex)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
 				}
-			default:
+			}
+
+			if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+				continue
+			}
+			if net.ParseIP(intf.If.Vxlan.SrcAddress) == nil {
 				errCnt++
-				errString := fmt.Sprintf("pod %s: unknown address type %+v", pod.Name, adr)
-				v.Report.AppendToNodeReport(vppNode.Name, errString)
+				errString := fmt.Sprintf("malformed VXLAN src address '%s' on interface %s (ifIndex %d)",
+					intf.If.Vxlan.SrcAddress, intf.If.Name, intf.IfMeta.SwIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+			}
+			if net.ParseIP(intf.If.Vxlan.DstAddress) == nil {
+				errCnt++
+				errString := fmt.Sprintf("malformed VXLAN dst address '%s' on interface %s (ifIndex %d)",
+					intf.If.Vxlan.DstAddress, intf.If.Name, intf.IfMeta.SwIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
 			}
 		}
 
-		// Skip over host-network pods
-		if pod.IPAddress == pod.HostIPAddress {
-			continue
+		for _, arpTableEntry := range node.NodeIPArp {
+			if net.ParseIP(arpTableEntry.Ae.IPAddress) == nil {
+				errCnt++
+				errString := fmt.Sprintf("malformed IP address in ARP entry <'%s'-'%s'> on interface %s",
+					arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress, arpTableEntry.Ae.Interface)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
+			}
 		}
+	}
 
-		k8sPodIPAdrParts := strings.Split(k8sNode.Pod_CIDR, "/")
-		k8sMaskLen, err := strconv.Atoi(k8sPodIPAdrParts[1])
-		if err != nil {
+	return errCnt
+}
+
+// ValidatePodHostBinding checks that the host IP address of each pod in the
+// K8s cache is the management IP address of a known node. Pods bound to an
+// unknown host indicate stale or cross-cluster data.
+func (v *Validator) ValidatePodHostBinding() {
+	errCnt := 0
+
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		if _, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress); err != nil {
 			errCnt++
-			errString := fmt.Sprintf("invalid Pod_CIDR %s", k8sNode.Pod_CIDR)
-			v.Report.AppendToNodeReport(k8sNode.Name, errString)
-			continue
+			errString := fmt.Sprintf("pod %s (namespace %s) is bound to unknown host IP %s",
+				pod.Name, pod.Namespace, pod.HostIPAddress)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryPods, errString)
 		}
-		k8sMask := maskLength2Mask(k8sMaskLen)
+	}
 
-		podIfIPCidrParts := strings.Split(vppNode.NodeIPam.Config.PodIfIPCIDR, "/")
-		podIfMaskLen, err := strconv.Atoi(podIfIPCidrParts[1])
-		if err != nil {
-			errCnt++
-			errString := fmt.Sprintf("invalid IPAM PodIfIPCIDR %s", vppNode.NodeIPam.Config.PodIfIPCIDR)
-			v.Report.AppendToNodeReport(k8sNode.Name, errString)
-			continue
+	v.addSummary(errCnt, "Pod host binding")
+}
+
+// ValidateDisabledInterfaceState checks that disabled interfaces do not have
+// any IP addresses configured, which usually signals an incomplete teardown.
+// It also checks that enabled interfaces have an IP address configured;
+// local0 and VXLAN tunnels (which are only bridged) are exempt from the check.
+func (v *Validator) ValidateDisabledInterfaceState() {
+	v.runNodeChecks(disabledInterfaceStateCheck)
+}
+
+// checkDisabledInterfaceState performs ValidateDisabledInterfaceState for the nodes in nodeList.
+func (v *Validator) checkDisabledInterfaceState(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if !intf.If.Enabled {
+				if len(intf.If.IPAddresses) > 0 {
+					errCnt++
+					errString := fmt.Sprintf("disabled interface %s (ifIndex %d) has IP addresses %v",
+						intf.If.Name, intf.IfMeta.SwIfIndex, intf.If.IPAddresses)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+				}
+				continue
+			}
+
+			if intf.IfMeta.VppInternalName == "local0" || intf.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL {
+				continue
+			}
+			if len(intf.If.IPAddresses) == 0 {
+				errCnt++
+				errString := fmt.Sprintf("enabled interface %s (ifIndex %d) has no IP address",
+					intf.If.Name, intf.IfMeta.SwIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
 		}
-		podIfIPMask := maskLength2Mask(podIfMaskLen)
+	}
+
+	return errCnt
+}
+
+// ValidateBridgeDomainCount checks that each node has the expected number
+// of bridge domains. Extra bridge domains are often left over from a failed
+// reconfiguration.
+func (v *Validator) ValidateBridgeDomainCount(expected int) {
+	v.runNodeChecks(bridgeDomainCountCheck(expected))
+}
+
+// checkBridgeDomainCount performs ValidateBridgeDomainCount for the nodes in nodeList.
+func (v *Validator) checkBridgeDomainCount(expected int, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
 
-		if k8sMask != podIfIPMask {
+	for _, node := range nodeList {
+		if len(node.NodeBridgeDomains) != expected {
 			errCnt++
-			errString := fmt.Sprintf("IP address mask mismatch: K8s Pod CIDR: %s, Contiv PodIfIpCIDR %s",
-				k8sNode.Pod_CIDR, vppNode.NodeIPam.Config.PodIfIPCIDR)
-			v.Report.AppendToNodeReport(k8sNode.Name, errString)
-			continue
+			errString := fmt.Sprintf("unexpected number of bridge domains: got %d, expected %d",
+				len(node.NodeBridgeDomains), expected)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
 		}
+	}
+
+	return errCnt
+}
 
-		// Populate Pod's VPP interface data (IP addresses, interface name and
-		// ifIndex)
-		podMap[pod.Name] = vppNode.Name
-		podIfIPPfx := ip2uint32(podIfIPCidrParts[0]) &^ podIfIPMask
-		podIP := ip2uint32(pod.IPAddress)
+// ValidateVxlanMtuHeadroom checks that the MTU of the GigE (underlay)
+// interface on each node leaves enough room for the VXLAN encapsulation
+// of packets sent from the tap (overlay) interfaces. Insufficient headroom
+// causes large packets to be fragmented or dropped.
+func (v *Validator) ValidateVxlanMtuHeadroom() {
+	v.runNodeChecks(vxlanMtuHeadroomCheck)
+}
 
-		for _, intf := range vppNode.NodeInterfaces {
-			if strings.Contains(intf.IfMeta.VppInternalName, "tap") {
-				for _, ip := range intf.If.IPAddresses {
+// checkVxlanMtuHeadroom performs ValidateVxlanMtuHeadroom for the nodes in nodeList.
+func (v *Validator) checkVxlanMtuHeadroom(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
 
-					ipAddr := strings.Split(ip, "/")
-					tapIP := ip2uint32(ipAddr[0])
-					if tapIP&^podIfIPMask != podIfIPPfx {
-						continue
-					}
+	overhead := v.VxlanOverhead
+	if overhead == 0 {
+		overhead = defaultVxlanOverhead
+	}
 
-					if (podIP & k8sMask) == (tapIP & podIfIPMask) {
-						pod.VppIfIPAddr = ip
-						pod.VppIfInternalName = intf.IfMeta.VppInternalName
-						pod.VppIfName = intf.If.Name
-						pod.VppSwIfIdx = intf.IfMeta.SwIfIndex
-						delete(podMap, pod.Name)
-						delete(tapMap[vppNode.Name], intf.IfMeta.SwIfIndex)
-					}
-				}
+	for _, node := range nodeList {
+		var gigE *telemetrymodel.NodeInterface
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD && intf.If.Mtu != 0 {
+				gigE = &intf
+				break
+			}
+		}
+		if gigE == nil {
+			continue
+		}
+
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE || intf.If.Mtu == 0 {
+				continue
+			}
+			if gigE.If.Mtu < intf.If.Mtu+overhead {
+				errCnt++
+				errString := fmt.Sprintf("insufficient MTU headroom: %s MTU %d < %s MTU %d + VXLAN overhead %d",
+					gigE.If.Name, gigE.If.Mtu, intf.If.Name, intf.If.Mtu, overhead)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
 			}
 		}
 	}
 
-	for podName, nodeName := range podMap {
+	return errCnt
+}
+
+// ValidateHubSpokeVxlan checks that each worker node has a VXLAN tunnel to
+// the specified master (hub) node and that the master has a VXLAN tunnel
+// back to each worker. Tunnels between worker nodes are not checked, so
+// this validation can be used for hub-spoke topologies where a full mesh
+// is not required.
+func (v *Validator) ValidateHubSpokeVxlan(masterNodeName string) {
+	errCnt := 0
+
+	master, err := v.VppCache.RetrieveNode(masterNodeName)
+	if err != nil {
 		errCnt++
-		errString := fmt.Sprintf("no valid VPP tap interface found for pod %s", podName)
-		v.Report.AppendToNodeReport(nodeName, errString)
+		errString := fmt.Sprintf("master node %s not found - skipping hub-spoke VXLAN validation",
+			masterNodeName)
+		v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryVxlan, errString)
+		v.addSummary(errCnt, "Hub-spoke VXLAN")
+		return
 	}
+	masterPeers := v.getVxlanTunnelPeers(master)
 
 	for _, node := range v.VppCache.RetrieveAllNodes() {
-		for ifIdx, intf := range tapMap[node.Name] {
+		if node.Name == master.Name {
+			continue
+		}
+
+		if !v.getVxlanTunnelPeers(node)[master.Name] {
 			errCnt++
-			errString := fmt.Sprintf("dangling pod-facing tap interface '%s' (vppName '%s', ifIndex %d)",
-				intf.If.Name, intf.IfMeta.VppInternalName, ifIdx)
-			v.Report.AppendToNodeReport(node.Name, errString)
+			errString := fmt.Sprintf("no vxlan_tunnel to master node %s", master.Name)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+		}
+
+		if !masterPeers[node.Name] {
+			errCnt++
+			errString := fmt.Sprintf("no vxlan_tunnel to worker node %s", node.Name)
+			v.Report.AppendToNodeReportWithCategory(master.Name, api.CategoryVxlan, errString)
 		}
 	}
 
-	v.addSummary(errCnt, "K8sPod")
+	v.addSummary(errCnt, "Hub-spoke VXLAN")
 }
 
-func (v *Validator) createTapMarkAndSweepDB() {
-
+// ValidateBviIpEncodesNodeId checks that the last octet of each node's
+// vxlanBVI IP address is equal to the node's ID (e.g. 192.168.30.3 for node
+// 3). The convention is not used by all clusters, so Validate() performs
+// this check only when BviIPEncodesNodeID is set.
+func (v *Validator) ValidateBviIpEncodesNodeId() {
+	v.runNodeChecks(bviIpEncodesNodeIdCheck)
 }
 
-func (v *Validator) addSummary(errCnt int, kind string) {
-	if errCnt == 0 {
-		v.Report.AppendToNodeReport(api.GlobalMsg, fmt.Sprintf("%s validation: OK", kind))
-	} else {
-		v.Report.AppendToNodeReport(api.GlobalMsg,
-			fmt.Sprintf("%s validation: %d error%s found", kind, errCnt, printS(errCnt)))
+// checkBviIpEncodesNodeId performs ValidateBviIpEncodesNodeId for the nodes in nodeList.
+func (v *Validator) checkBviIpEncodesNodeId(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		loopIf, err := datastore.GetNodeLoopIFInfo(node)
+		if err != nil {
+			continue
+		}
+
+		for _, ipAddr := range loopIf.If.IPAddresses {
+			ip, _, err := net.ParseCIDR(ipAddr)
+			if err != nil || ip.To4() == nil {
+				continue
+			}
+			if hostOctet := uint32(ip.To4()[3]); hostOctet != node.ID {
+				errCnt++
+				errString := fmt.Sprintf("BVI IP address %s does not encode node ID: got %d, expected %d",
+					ipAddr, hostOctet, node.ID)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
 	}
+
+	return errCnt
 }
 
-func getVxlanBD(node *telemetrymodel.Node) (int, error) {
-	for bdomainIdx, bdomain := range node.NodeBridgeDomains {
-		if bdomain.Bd.Name == "vxlanBD" {
-			return bdomainIdx, nil
+// ValidateLoopbackCount checks that each node has exactly one loopback
+// interface (the vxlanBVI). Extra loopbacks are usually left over from a
+// previous configuration and make the BVI lookup ambiguous.
+func (v *Validator) ValidateLoopbackCount() {
+	v.runNodeChecks(loopbackCountCheck)
+}
+
+// checkLoopbackCount performs ValidateLoopbackCount for the nodes in nodeList.
+func (v *Validator) checkLoopbackCount(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		loopNames := make([]string, 0)
+		for _, intf := range node.NodeInterfaces {
+			if strings.HasPrefix(intf.IfMeta.VppInternalName, "loop") && !v.isExpectedExtraInterface(intf.If.Name) {
+				loopNames = append(loopNames, intf.If.Name)
+			}
+		}
+		sort.Strings(loopNames)
+
+		if len(loopNames) != 1 {
+			errCnt++
+			errString := fmt.Sprintf("unexpected number of loopback interfaces: got %d, expected 1; "+
+				"loopbacks found: %v", len(loopNames), loopNames)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
 		}
 	}
-	return 0, fmt.Errorf("vxlanBD not found")
+
+	return errCnt
 }
 
-// maskLength2Mask will tank in an int and return the bit mask for the number given
-func maskLength2Mask(ml int) uint32 {
-	var mask uint32
-	for i := 0; i < 32-ml; i++ {
-		mask = mask << 1
-		mask++
-	}
-	return mask
+// ValidateL2FibStaticness checks that all L2FIB entries in bridge domains
+// that are expected to be fully static (by default the vxlanBD) are
+// statically configured. Dynamically learned entries in these bridge domains
+// indicate that MAC learning was not disabled.
+func (v *Validator) ValidateL2FibStaticness() {
+	v.runNodeChecks(l2FibStaticnessCheck)
 }
 
-func ip2uint32(ipAddress string) uint32 {
-	var ipu uint32
-	parts := strings.Split(ipAddress, ".")
-	for _, p := range parts {
-		// num, _ := strconv.ParseUint(p, 10, 32)
-		num, _ := strconv.Atoi(p)
-		ipu = (ipu << 8) + uint32(num)
-		//fmt.Printf("%d: num: 0x%x, ipu: 0x%x\n", i, num, ipu)
+// checkL2FibStaticness performs ValidateL2FibStaticness for the nodes in nodeList.
+func (v *Validator) checkL2FibStaticness(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	staticBDs := make(map[string]bool)
+	bdNames := v.StaticFibBDs
+	if len(bdNames) == 0 {
+		bdNames = defaultStaticFibBDs
+	}
+	for _, bdName := range bdNames {
+		staticBDs[bdName] = true
 	}
-	return ipu
-}
 
-func printS(errCnt int) string {
-	if errCnt > 1 {
-		return "s"
+	for _, node := range nodeList {
+		for _, fibEntry := range node.NodeL2Fibs {
+			if staticBDs[fibEntry.Fe.BridgeDomainName] && !fibEntry.Fe.StaticConfig {
+				errCnt++
+				errString := fmt.Sprintf("non-static L2Fib entry for MAC %s in BD %s",
+					fibEntry.Fe.PhysAddress, fibEntry.Fe.BridgeDomainName)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
+			}
+		}
 	}
-	return ""
+
+	return errCnt
+}
+
+// ValidateRequiredPodLabels checks that pods carry the labels required by
+// Contiv. The 'required' map specifies, for each namespace, the keys of the
+// labels that every pod in the namespace must have. If a pod has a label
+// whose key is a near-miss of a missing required key (e.g. a typo), the
+// unexpected key is reported along with the missing one.
+func (v *Validator) ValidateRequiredPodLabels(required map[string][]string) {
+	errCnt := 0
+
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		requiredKeys, ok := required[pod.Namespace]
+		if !ok {
+			continue
+		}
+
+		podLabels := make(map[string]bool)
+		for _, label := range pod.Label {
+			podLabels[label.Key] = true
+		}
+
+		reportNode := api.GlobalMsg
+		if node, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress); err == nil {
+			reportNode = node.Name
+		}
+
+		for _, key := range requiredKeys {
+			if podLabels[key] {
+				continue
+			}
+
+			errCnt++
+			errString := fmt.Sprintf("pod %s (namespace %s) is missing label %s",
+				pod.Name, pod.Namespace, key)
+			for _, label := range pod.Label {
+				if d := editDistance(label.Key, key); d > 0 && d <= maxLabelKeyTypoDistance {
+					errString += fmt.Sprintf("; unexpected label %s (typo?)", label.Key)
+				}
+			}
+			v.Report.AppendToNodeReportWithCategory(reportNode, api.CategoryPods, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Pod labels")
+}
+
+// ValidateIpPrefixDisjointness checks that interface IP prefixes that fall
+// into any of the specified prefix classes (e.g. the pod or host network
+// CIDRs) are disjoint across nodes, i.e. that no two nodes have overlapping
+// prefixes from the same class. Addresses on the vxlanBVI interface are
+// exempt, because the BVIs of all nodes share a single subnet.
+func (v *Validator) ValidateIpPrefixDisjointness(classes []string) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	classNets := make([]*net.IPNet, 0)
+	for _, class := range classes {
+		_, classNet, err := net.ParseCIDR(class)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("invalid prefix class %s: %s", class, err)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryNodes, errString)
+			continue
+		}
+		classNets = append(classNets, classNet)
+	}
+
+	// Collect the prefixes that fall into one of the classes for each node
+	nodePrefixes := make([][]*net.IPNet, len(nodeList))
+	for i, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.IfMeta.VppInternalName == "loop0" {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				_, prefix, err := net.ParseCIDR(ipAddr)
+				if err != nil {
+					continue
+				}
+				for _, classNet := range classNets {
+					if classNet.Contains(prefix.IP) {
+						nodePrefixes[i] = append(nodePrefixes[i], prefix)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	for i := range nodeList {
+		for j := i + 1; j < len(nodeList); j++ {
+			for _, p1 := range nodePrefixes[i] {
+				for _, p2 := range nodePrefixes[j] {
+					if p1.Contains(p2.IP) || p2.Contains(p1.IP) {
+						errCnt++
+						errString := fmt.Sprintf("prefix %s overlaps prefix %s on node %s",
+							p1, p2, nodeList[j].Name)
+						v.Report.AppendToNodeReportWithCategory(nodeList[i].Name, api.CategoryNodes, errString)
+					}
+				}
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "IP prefix disjointness")
+}
+
+// ValidateSplitHorizonGroups checks that all VXLAN tunnel interfaces in a
+// bridge domain are in the expected split-horizon group. Tunnels in mixed
+// or zero split-horizon groups can forward traffic received from one tunnel
+// to another, causing L2 loops.
+func (v *Validator) ValidateSplitHorizonGroups() {
+	v.runNodeChecks(splitHorizonGroupsCheck)
+}
+
+// checkSplitHorizonGroups performs ValidateSplitHorizonGroups for the nodes in nodeList.
+func (v *Validator) checkSplitHorizonGroups(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	expected := v.SplitHorizonGroup
+	if expected == 0 {
+		expected = defaultSplitHorizonGroup
+	}
+
+	for _, node := range nodeList {
+		for _, bd := range node.NodeBridgeDomains {
+			bdName2Id := make(map[string]uint32)
+			for id, name := range bd.BdMeta.BdID2Name {
+				bdName2Id[name] = id
+			}
+
+			for _, bdIfc := range bd.Bd.Interfaces {
+				nodeIfc, ok := node.NodeInterfaces[int(bdName2Id[bdIfc.Name])]
+				if !ok || nodeIfc.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+					continue
+				}
+				if bdIfc.SplitHorizonGrp != expected {
+					errCnt++
+					errString := fmt.Sprintf("vxlan_tunnel %s in BD %s has split-horizon group %d, expected %d",
+						bdIfc.Name, bd.Bd.Name, bdIfc.SplitHorizonGrp, expected)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
+				}
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateVxlanUnderlayReachability checks that the destination address of
+// each VXLAN tunnel is plausibly reachable through the underlay, i.e. that
+// it is either in the subnet of the node's GigE interface or present in
+// the node's ARP table. Tunnels failing the check are typically left over
+// from an underlay renumbering.
+func (v *Validator) ValidateVxlanUnderlayReachability() {
+	v.runNodeChecks(vxlanUnderlayReachabilityCheck)
+}
+
+// checkVxlanUnderlayReachability performs ValidateVxlanUnderlayReachability for the nodes in nodeList.
+func (v *Validator) checkVxlanUnderlayReachability(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		gigESubnets := make([]*net.IPNet, 0)
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_ETHERNET_CSMACD {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				if _, subnet, err := net.ParseCIDR(ipAddr); err == nil {
+					gigESubnets = append(gigESubnets, subnet)
+				}
+			}
+		}
+
+		arpIPs := make(map[string]bool)
+		for _, arpEntry := range node.NodeIPArp {
+			arpIPs[arpEntry.Ae.IPAddress] = true
+		}
+
+	nextTunnel:
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+				continue
+			}
+
+			dstAddr := intf.If.Vxlan.DstAddress
+			if arpIPs[dstAddr] {
+				continue
+			}
+			if dstIP := net.ParseIP(dstAddr); dstIP != nil {
+				for _, subnet := range gigESubnets {
+					if subnet.Contains(dstIP) {
+						continue nextTunnel
+					}
+				}
+			}
+
+			errCnt++
+			errString := fmt.Sprintf("vxlan_tunnel %s destination %s is neither in the GigE subnet "+
+				"nor in the ARP table", intf.If.Name, dstAddr)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateInterfaceIndexConsistency checks that each interface is stored
+// under its own sw_if_index and that all sw_if_indices referenced from
+// bridge domains, L2Fib entries and ARP entries resolve to an interface on
+// the node. Inconsistent indices usually point to a data collection or
+// deserialization problem.
+func (v *Validator) ValidateInterfaceIndexConsistency() {
+	v.runNodeChecks(interfaceIndexConsistencyCheck)
+}
+
+// checkInterfaceIndexConsistency performs ValidateInterfaceIndexConsistency for the nodes in nodeList.
+func (v *Validator) checkInterfaceIndexConsistency(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for ifIndex, intf := range node.NodeInterfaces {
+			if uint32(ifIndex) != intf.IfMeta.SwIfIndex {
+				errCnt++
+				errString := fmt.Sprintf("interface %s stored under ifIndex %d has sw_if_index %d",
+					intf.If.Name, ifIndex, intf.IfMeta.SwIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+
+		for _, bd := range node.NodeBridgeDomains {
+			for ifIndex, ifName := range bd.BdMeta.BdID2Name {
+				if _, ok := node.NodeInterfaces[int(ifIndex)]; !ok {
+					errCnt++
+					errString := fmt.Sprintf("BD %s interface %s references invalid ifIndex %d",
+						bd.Bd.Name, ifName, ifIndex)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+				}
+			}
+		}
+
+		for _, fibEntry := range node.NodeL2Fibs {
+			if _, ok := node.NodeInterfaces[int(fibEntry.FeMeta.OutgoingIfIndex)]; !ok {
+				errCnt++
+				errString := fmt.Sprintf("L2Fib entry for MAC %s references invalid ifIndex %d",
+					fibEntry.Fe.PhysAddress, fibEntry.FeMeta.OutgoingIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+
+		for _, arpEntry := range node.NodeIPArp {
+			if _, ok := node.NodeInterfaces[int(arpEntry.AeMeta.IfIndex)]; !ok {
+				errCnt++
+				errString := fmt.Sprintf("ARP entry <'%s'-'%s'> references invalid ifIndex %d",
+					arpEntry.Ae.PhysAddress, arpEntry.Ae.IPAddress, arpEntry.AeMeta.IfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateManagementIpMatch checks that the management IP address of each
+// Contiv node is listed among the InternalIP addresses of its K8s node
+// counterpart. Nodes missing in the K8s database are reported by
+// ValidateK8sNodeInfo and skipped here.
+func (v *Validator) ValidateManagementIpMatch() {
+	v.runNodeChecks(managementIpMatchCheck)
+}
+
+// checkManagementIpMatch performs ValidateManagementIpMatch for the nodes in nodeList.
+func (v *Validator) checkManagementIpMatch(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		k8sNode, err := v.K8sCache.RetrieveK8sNode(node.Name)
+		if err != nil {
+			continue
+		}
+
+		internalIPs := make([]string, 0)
+		found := false
+		for _, adr := range k8sNode.Addresses {
+			if adr.Type != nodemodel.NodeAddress_NodeInternalIP {
+				continue
+			}
+			internalIPs = append(internalIPs, adr.Address)
+			if adr.Address == node.ManIPAddr {
+				found = true
+			}
+		}
+
+		if !found {
+			errCnt++
+			errString := fmt.Sprintf("management IP address %s not found in K8s node InternalIP addresses %v",
+				node.ManIPAddr, internalIPs)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateAgainstExpected compares the interfaces collected from each node
+// with the expected (i.e. configured) interfaces for the node. Expected
+// interfaces are keyed by node name; nodes not present in expected are not
+// checked. Interfaces are matched by name; for each matched interface, its
+// MTU, IP addresses and admin state are compared.
+func (v *Validator) ValidateAgainstExpected(expected map[string]telemetrymodel.NodeInterfaces) {
+	errCnt := 0
+
+	nodeNames := make([]string, 0, len(expected))
+	for nodeName := range expected {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		node, err := v.VppCache.RetrieveNode(nodeName)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("node %s with expected interface config not found", nodeName)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryInterfaces, errString)
+			continue
+		}
+
+		collected := make(map[string]telemetrymodel.Interface)
+		for _, intf := range node.NodeInterfaces {
+			collected[intf.If.Name] = intf.If
+		}
+
+		ifNames := make([]string, 0, len(expected[nodeName]))
+		for _, intf := range expected[nodeName] {
+			ifNames = append(ifNames, intf.If.Name)
+		}
+		sort.Strings(ifNames)
+
+		expectedIfs := make(map[string]telemetrymodel.Interface)
+		for _, intf := range expected[nodeName] {
+			expectedIfs[intf.If.Name] = intf.If
+		}
+
+		for _, ifName := range ifNames {
+			exp := expectedIfs[ifName]
+			act, ok := collected[ifName]
+			if !ok {
+				errCnt++
+				errString := fmt.Sprintf("interface %s: present in config, missing in collected data", ifName)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+				continue
+			}
+
+			if exp.Mtu != act.Mtu {
+				errCnt++
+				errString := fmt.Sprintf("interface %s: MTU mismatch - expected %d, collected %d",
+					ifName, exp.Mtu, act.Mtu)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+
+			expIPs := sortedCopy(exp.IPAddresses)
+			actIPs := sortedCopy(act.IPAddresses)
+			if strings.Join(expIPs, ",") != strings.Join(actIPs, ",") {
+				errCnt++
+				errString := fmt.Sprintf("interface %s: IP address mismatch - expected %v, collected %v",
+					ifName, expIPs, actIPs)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+
+			if exp.Enabled != act.Enabled {
+				errCnt++
+				errString := fmt.Sprintf("interface %s: enabled mismatch - expected %t, collected %t",
+					ifName, exp.Enabled, act.Enabled)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+
+		extras := make([]string, 0)
+		for ifName := range collected {
+			if _, ok := expectedIfs[ifName]; !ok && !v.isExpectedExtraInterface(ifName) {
+				extras = append(extras, ifName)
+			}
+		}
+		sort.Strings(extras)
+		for _, ifName := range extras {
+			errCnt++
+			errString := fmt.Sprintf("interface %s: present in collected data, not in config", ifName)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Expected interface config")
+}
+
+// ValidateEtcdPlacement checks that all contiv-etcd pods run on the
+// specified master node. An etcd pod rescheduled to a different node is
+// reported on the node where it runs.
+func (v *Validator) ValidateEtcdPlacement(masterNodeName string) {
+	errCnt := 0
+
+	master, err := v.VppCache.RetrieveNode(masterNodeName)
+	if err != nil {
+		errCnt++
+		errString := fmt.Sprintf("master node %s not found - skipping etcd placement validation",
+			masterNodeName)
+		v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryPods, errString)
+		v.addSummary(errCnt, "Etcd placement")
+		return
+	}
+
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		isEtcd := false
+		for _, label := range pod.Label {
+			if label.Key == etcdPodLabelKey && label.Value == etcdPodLabelValue {
+				isEtcd = true
+				break
+			}
+		}
+		if !isEtcd {
+			continue
+		}
+
+		node, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("etcd pod %s host IP address %s does not map to any node",
+				pod.Name, pod.HostIPAddress)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryPods, errString)
+			continue
+		}
+
+		if node.Name != master.Name {
+			errCnt++
+			errString := fmt.Sprintf("etcd pod %s runs on node %s instead of master node %s",
+				pod.Name, node.Name, master.Name)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryPods, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Etcd placement")
+}
+
+// ValidateTelemetryCounters checks the counters in the telemetry data
+// collected from each node against the specified thresholds, which are
+// keyed by counter reason (e.g. a drop or error reason). Counters whose
+// reason has no threshold are ignored.
+func (v *Validator) ValidateTelemetryCounters(thresholds map[string]int) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	for _, node := range nodeList {
+		keys := make([]string, 0, len(node.NodeTelemetry))
+		for key := range node.NodeTelemetry {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, output := range node.NodeTelemetry[key].Output {
+				for _, entry := range output.Output {
+					threshold, ok := thresholds[entry.Reason]
+					if !ok || entry.Count <= threshold {
+						continue
+					}
+
+					errCnt++
+					errString := fmt.Sprintf("telemetry counter '%s' (command '%s') is %d, exceeds threshold %d",
+						entry.Reason, output.Command, entry.Count, threshold)
+					v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryTelemetry, errString)
+				}
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "Telemetry counters")
+}
+
+// ValidateJumboFrames checks that the MTU of each GigabitEthernet interface
+// is at least minMtu. The underlay must support jumbo frames to provide
+// headroom for the VXLAN encapsulation. Interfaces with unknown MTU (0) are
+// skipped.
+func (v *Validator) ValidateJumboFrames(minMtu uint32) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_ETHERNET_CSMACD || intf.If.Mtu == 0 {
+				continue
+			}
+			if intf.If.Mtu < minMtu {
+				errCnt++
+				errString := fmt.Sprintf("interface %s MTU %d is below the minimum jumbo frame MTU %d",
+					intf.If.Name, intf.If.Mtu, minMtu)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "Jumbo frames")
+}
+
+// ValidateBridgeDomainNaming checks that the primary bridge domain on each
+// node, i.e. the bridge domain with the BVI interface, is named
+// expectedName. defaultVxlanBDName is used if expectedName is empty.
+func (v *Validator) ValidateBridgeDomainNaming(expectedName string) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	if expectedName == "" {
+		expectedName = defaultVxlanBDName
+	}
+
+	for _, node := range nodeList {
+		var primary *telemetrymodel.NodeBridgeDomain
+		for _, bd := range node.NodeBridgeDomains {
+			for _, intf := range bd.Bd.Interfaces {
+				if intf.BVI {
+					primary = &bd
+					break
+				}
+			}
+			if primary != nil {
+				break
+			}
+		}
+
+		if primary == nil {
+			errCnt++
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain,
+				"primary bridge domain (with BVI interface) not found")
+			continue
+		}
+
+		if primary.Bd.Name != expectedName {
+			errCnt++
+			errString := fmt.Sprintf("primary bridge domain is named %s, expected %s",
+				primary.Bd.Name, expectedName)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "BD naming")
+}
+
+// ValidateLoopbackSubnetMembership checks that the vxlanBVI loopback IP
+// address of each node belongs to the specified overlay subnet. Nodes
+// without a BVI address are reported as well, BVI addresses of a different
+// address family than the subnet are reported as family mismatches.
+func (v *Validator) ValidateLoopbackSubnetMembership(subnet string) {
+	errCnt := 0
+
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		errCnt++
+		errString := fmt.Sprintf("invalid BVI subnet %s - skipping BVI subnet validation", subnet)
+		v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryInterfaces, errString)
+		v.addSummary(errCnt, "BVI subnet")
+		return
+	}
+
+	for _, node := range v.VppCache.RetrieveAllNodes() {
+		loopIf, err := datastore.GetNodeLoopIFInfo(node)
+		if err != nil || len(loopIf.If.IPAddresses) == 0 {
+			errCnt++
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, "BVI address missing")
+			continue
+		}
+
+		for _, ipAddr := range loopIf.If.IPAddresses {
+			ip, _, err := net.ParseCIDR(ipAddr)
+			if err == nil && ipFamilyMismatch(ip, ipNet) {
+				errCnt++
+				errString := fmt.Sprintf("BVI IP address family mismatch: %s address %s, %s subnet %s",
+					ipFamily(ip), ipAddr, netFamily(ipNet), ipNet.String())
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+				continue
+			}
+			if err != nil || !ipNet.Contains(ip) {
+				errCnt++
+				errString := fmt.Sprintf("BVI IP address %s is not in the expected subnet %s",
+					ipAddr, ipNet.String())
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "BVI subnet")
+}
+
+// ValidateGigEIpUniqueness checks that no IP address is configured on the
+// GigabitEthernet interfaces of more than one node. Each node sharing a
+// duplicate IP address is reported.
+func (v *Validator) ValidateGigEIpUniqueness() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	ipNodes := make(map[string][]string)
+	for _, node := range nodeList {
+		nodeIPs := make(map[string]bool)
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_ETHERNET_CSMACD {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				ip, _, err := net.ParseCIDR(ipAddr)
+				if err != nil || nodeIPs[ip.String()] {
+					continue
+				}
+				nodeIPs[ip.String()] = true
+				ipNodes[ip.String()] = append(ipNodes[ip.String()], node.Name)
+			}
+		}
+	}
+
+	ips := make([]string, 0, len(ipNodes))
+	for ip := range ipNodes {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	for _, ip := range ips {
+		nodeNames := ipNodes[ip]
+		if len(nodeNames) < 2 {
+			continue
+		}
+		for _, nodeName := range nodeNames {
+			errCnt++
+			errString := fmt.Sprintf("duplicate GigE IP address %s, shared by nodes %s",
+				ip, strings.Join(nodeNames, ", "))
+			v.Report.AppendToNodeReportWithCategory(nodeName, api.CategoryInterfaces, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "GigE IP uniqueness")
+}
+
+// ValidateTapToPodParity checks that the number of pod-facing tap
+// interfaces (tap interfaces with a /32 IP address) on each node equals the
+// number of pods with their own IP address (i.e. not in the host network)
+// scheduled on the node. Taps without a pod are usually leaked after pod
+// deletion.
+func (v *Validator) ValidateTapToPodParity() {
+	v.runNodeChecks(tapToPodParityCheck)
+}
+
+// checkTapToPodParity performs ValidateTapToPodParity for the nodes in nodeList.
+func (v *Validator) checkTapToPodParity(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		tapCnt := 0
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				if strings.HasSuffix(ipAddr, "/32") {
+					tapCnt++
+					break
+				}
+			}
+		}
+
+		podCnt := 0
+		for _, pod := range v.K8sCache.RetrievePodsByHostIPAddr(node.ManIPAddr) {
+			if pod.IPAddress != "" && pod.IPAddress != pod.HostIPAddress {
+				podCnt++
+			}
+		}
+
+		switch {
+		case tapCnt > podCnt:
+			errCnt++
+			errString := fmt.Sprintf("%d pod tap(s) without a pod: pod tap count %d, pod count %d",
+				tapCnt-podCnt, tapCnt, podCnt)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryPods, errString)
+		case podCnt > tapCnt:
+			errCnt++
+			errString := fmt.Sprintf("%d pod(s) without a pod tap: pod tap count %d, pod count %d",
+				podCnt-tapCnt, tapCnt, podCnt)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryPods, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateOrphanedVxlanTunnels checks that each VXLAN tunnel interface on a
+// node is a member of a bridge domain. A tunnel that was created but never
+// attached to a bridge domain carries no traffic.
+func (v *Validator) ValidateOrphanedVxlanTunnels() {
+	v.runNodeChecks(orphanedVxlanTunnelsCheck)
+}
+
+// checkOrphanedVxlanTunnels performs ValidateOrphanedVxlanTunnels for the nodes in nodeList.
+func (v *Validator) checkOrphanedVxlanTunnels(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		bdMembers := make(map[uint32]bool)
+		for _, bd := range node.NodeBridgeDomains {
+			bdName2Id := make(map[string]uint32)
+			for id, name := range bd.BdMeta.BdID2Name {
+				bdName2Id[name] = id
+			}
+			for _, bdIfc := range bd.Bd.Interfaces {
+				if id, ok := bdName2Id[bdIfc.Name]; ok {
+					bdMembers[id] = true
+				}
+			}
+		}
+
+		for ifIdx, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+				continue
+			}
+			if !bdMembers[uint32(ifIdx)] {
+				errCnt++
+				errString := fmt.Sprintf("vxlan_tunnel %s (ifIndex %d) is not a member of any bridge domain",
+					intf.If.Name, ifIdx)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateHostTapAddressing checks the IPv4 and IPv6 addresses of the host
+// interconnect taps (tap-vpp2). The host tap IP address must be unique
+// across nodes and the subnet index encoded in it (see subnetIndex), e.g.
+// the third octet of an address in a /24 IPv4 subnet, must match the node
+// ID. Addresses whose prefix is too short to encode a subnet index are
+// reported as warnings.
+func (v *Validator) ValidateHostTapAddressing() {
+	v.runNodeChecks(hostTapAddressingCheck(v.VppCache.RetrieveAllNodes()))
+}
+
+// hostTapIPNodes returns the names of the nodes in nodeList that have the
+// host tap IP address, for each host tap IP address.
+func hostTapIPNodes(nodeList []*telemetrymodel.Node) map[string][]string {
+	ipNodes := make(map[string][]string)
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.IfMeta.Tag != hostTapTag {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				if ip, _, err := net.ParseCIDR(ipAddr); err == nil {
+					ipNodes[ip.String()] = append(ipNodes[ip.String()], node.Name)
+				}
+			}
+		}
+	}
+	return ipNodes
+}
+
+// checkHostTapAddressing performs ValidateHostTapAddressing for the nodes
+// in nodeList; ipNodes are the nodes of each host tap IP address in the
+// cluster (see hostTapIPNodes).
+func (v *Validator) checkHostTapAddressing(ipNodes map[string][]string, nodeList []*telemetrymodel.Node,
+	report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		ips := make([]string, 0)
+		for _, intf := range node.NodeInterfaces {
+			if intf.IfMeta.Tag != hostTapTag {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				ip, ipNet, err := net.ParseCIDR(ipAddr)
+				if err != nil {
+					continue
+				}
+				ips = append(ips, ip.String())
+
+				subnetIdx, ok := subnetIndex(ip, ipNet)
+				if !ok {
+					report.Append(node.Name, api.SeverityWarning, api.CategoryInterfaces,
+						fmt.Sprintf("host tap %s address %s: prefix too short to encode a subnet index, "+
+							"subnet index not checked", ipFamily(ip), ipAddr))
+					continue
+				}
+				if subnetIdx != node.ID {
+					errCnt++
+					errString := fmt.Sprintf("host tap %s address %s has subnet index %d, expected node ID %d",
+						ipFamily(ip), ip, subnetIdx, node.ID)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+				}
+			}
+		}
+
+		sort.Strings(ips)
+		for _, ip := range ips {
+			nodeNames := ipNodes[ip]
+			if len(nodeNames) < 2 {
+				continue
+			}
+			errCnt++
+			errString := fmt.Sprintf("duplicate host tap IP address %s, shared by nodes %s",
+				ip, strings.Join(nodeNames, ", "))
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateDaemonSetGeneration checks that all pods with the specified
+// k8s-app label value share the same pod-template-generation and
+// controller-revision-hash. Pods of a DaemonSet running different template
+// revisions indicate a stuck rollout. Pods whose revision differs from the
+// revision of the majority of pods are reported.
+func (v *Validator) ValidateDaemonSetGeneration(appLabel string) {
+	errCnt := 0
+
+	pods := make([]*telemetrymodel.Pod, 0)
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		if podHasLabel(pod, appLabelKey, appLabel) {
+			pods = append(pods, pod)
+		}
+	}
+
+	for _, revLabel := range daemonSetRevisionLabels {
+		podRevs := make(map[*telemetrymodel.Pod]string)
+		revCnt := make(map[string]int)
+		for _, pod := range pods {
+			for _, label := range pod.Label {
+				if label.Key == revLabel {
+					podRevs[pod] = label.Value
+					revCnt[label.Value]++
+				}
+			}
+		}
+		if len(revCnt) < 2 {
+			continue
+		}
+
+		revs := make([]string, 0, len(revCnt))
+		for rev := range revCnt {
+			revs = append(revs, rev)
+		}
+		sort.Strings(revs)
+		expected := revs[0]
+		for _, rev := range revs {
+			if revCnt[rev] > revCnt[expected] {
+				expected = rev
+			}
+		}
+
+		for _, pod := range pods {
+			rev, ok := podRevs[pod]
+			if !ok || rev == expected {
+				continue
+			}
+
+			reportNode := api.GlobalMsg
+			if node, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress); err == nil {
+				reportNode = node.Name
+			}
+
+			errCnt++
+			errString := fmt.Sprintf("pod %s (app %s) has %s %s, other pods have %s",
+				pod.Name, appLabel, revLabel, rev, expected)
+			v.Report.AppendToNodeReportWithCategory(reportNode, api.CategoryPods, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "DaemonSet generation")
+}
+
+// ValidateMinimumNodeCount checks that the cluster has at least the
+// specified number of nodes. A shortfall, e.g. after nodes silently dropped
+// out of the cluster, is reported as a global error.
+func (v *Validator) ValidateMinimumNodeCount(minNodes int) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	if len(nodeList) < minNodes {
+		errCnt++
+		errString := fmt.Sprintf("too few nodes in the cluster: got %d, expected at least %d",
+			len(nodeList), minNodes)
+		v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryNodes, errString)
+	}
+
+	v.addSummary(errCnt, "Minimum node count")
+}
+
+// ValidateConfiguredVni checks that all VXLAN tunnel interfaces on all nodes
+// use the configured VNI, including tunnels that are not in a bridge
+// domain.
+func (v *Validator) ValidateConfiguredVni() {
+	v.runNodeChecks(configuredVniCheck)
+}
+
+// checkConfiguredVni performs ValidateConfiguredVni for the nodes in nodeList.
+func (v *Validator) checkConfiguredVni(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	expected := v.VNI
+	if expected == 0 {
+		expected = api.VppVNI
+	}
+
+	for _, node := range nodeList {
+		for ifIdx, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+				continue
+			}
+			if intf.If.Vxlan.Vni != expected {
+				errCnt++
+				errString := fmt.Sprintf("vxlan_tunnel %s (ifIndex %d) has VNI %d, expected %d",
+					intf.If.Name, ifIdx, intf.If.Vxlan.Vni, expected)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateDnsPodReachability checks that each kube-dns pod is reachable on
+// the overlay, i.e. that its IP address is in the pod network of its host
+// node and that the host node has the pod's tap interface. The pod network
+// is taken from the node's Contiv IPAM data, which is what pod addresses
+// are allocated from.
+func (v *Validator) ValidateDnsPodReachability() {
+	errCnt := 0
+
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		if !podHasLabel(pod, appLabelKey, dnsPodLabelValue) {
+			continue
+		}
+
+		vppNode, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("DNS pod %s unreachable: host node with IP address %s not found",
+				pod.Name, pod.HostIPAddress)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryPods, errString)
+			continue
+		}
+		if vppNode.NodeIPam == nil {
+			errCnt++
+			errString := fmt.Sprintf("DNS pod %s unreachable: IPAM data for node not available", pod.Name)
+			v.Report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+			continue
+		}
+
+		podIP := net.ParseIP(pod.IPAddress)
+		_, podNet, err := net.ParseCIDR(vppNode.NodeIPam.PodNetwork)
+		if err == nil && podIP != nil && ipFamilyMismatch(podIP, podNet) {
+			errCnt++
+			errString := fmt.Sprintf("DNS pod %s unreachable: IP address family mismatch: %s address %s, "+
+				"%s pod network %s", pod.Name, ipFamily(podIP), pod.IPAddress, netFamily(podNet),
+				vppNode.NodeIPam.PodNetwork)
+			v.Report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+			continue
+		}
+		if err != nil || podIP == nil || !podNet.Contains(podIP) {
+			errCnt++
+			errString := fmt.Sprintf("DNS pod %s unreachable: IP address %s not in pod network %s",
+				pod.Name, pod.IPAddress, vppNode.NodeIPam.PodNetwork)
+			v.Report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+			continue
+		}
+
+		if !hasPodTap(vppNode, podIP, podNet) {
+			errCnt++
+			errString := fmt.Sprintf("DNS pod %s unreachable: tap interface for IP address %s not found",
+				pod.Name, pod.IPAddress)
+			v.Report.AppendToNodeReportWithCategory(vppNode.Name, api.CategoryPods, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "DNS pod reachability")
+}
+
+// ValidateVrfAssignment checks that each interface is in the VRF expected
+// for its interface type. Interfaces of types not in expected, and the
+// local0 interface, are not checked. An interface in the wrong VRF is
+// usually attached to the wrong routing table.
+func (v *Validator) ValidateVrfAssignment(expected map[interfaces.InterfaceType]uint32) {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	for _, node := range nodeList {
+		for ifIdx, intf := range node.NodeInterfaces {
+			if intf.IfMeta.VppInternalName == "local0" {
+				continue
+			}
+			expectedVrf, ok := expected[intf.If.IfType]
+			if !ok {
+				continue
+			}
+			if intf.If.Vrf != expectedVrf {
+				errCnt++
+				errString := fmt.Sprintf("interface %s (ifIndex %d, type %s) is in VRF %d, expected VRF %d",
+					intf.If.Name, ifIdx, intf.If.IfType, intf.If.Vrf, expectedVrf)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "VRF assignment")
+}
+
+// ValidateTapVersionUniformity checks that all tap interfaces in the
+// cluster use the same tap version. Tap interfaces whose version differs
+// from the version used by the majority of taps in the cluster are
+// reported; they usually indicate a partially upgraded cluster. Nodes
+// without tap interfaces are skipped.
+func (v *Validator) ValidateTapVersionUniformity() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	versionCnt := make(map[uint32]int)
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType == interfaces.InterfaceType_TAP_INTERFACE {
+				versionCnt[intf.If.Tap.Version]++
+			}
+		}
+	}
+
+	if len(versionCnt) > 1 {
+		var expected uint32
+		for version, cnt := range versionCnt {
+			if cnt > versionCnt[expected] || (cnt == versionCnt[expected] && version < expected) {
+				expected = version
+			}
+		}
+
+		for _, node := range nodeList {
+			for ifIdx, intf := range node.NodeInterfaces {
+				if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE || intf.If.Tap.Version == expected {
+					continue
+				}
+				errCnt++
+				errString := fmt.Sprintf("tap interface %s (ifIndex %d) has version %d, cluster majority is %d",
+					intf.If.Name, ifIdx, intf.If.Tap.Version, expected)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "Tap version")
+}
+
+// ValidateBdHasBvi checks that each bridge domain on each node has exactly
+// one member interface designated as the BVI. A bridge domain without a
+// BVI cannot route traffic in or out, and multiple BVIs make the routing
+// ambiguous.
+func (v *Validator) ValidateBdHasBvi() {
+	v.runNodeChecks(bdHasBviCheck)
+}
+
+// checkBdHasBvi performs ValidateBdHasBvi for the nodes in nodeList.
+func (v *Validator) checkBdHasBvi(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for _, bd := range node.NodeBridgeDomains {
+			bviNames := make([]string, 0)
+			for _, intf := range bd.Bd.Interfaces {
+				if intf.BVI {
+					bviNames = append(bviNames, intf.Name)
+				}
+			}
+
+			if len(bviNames) != 1 {
+				errCnt++
+				errString := fmt.Sprintf("bridge domain %s has %d BVI interfaces, expected 1; BVIs found: %v",
+					bd.Bd.Name, len(bviNames), bviNames)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidatePodGatewayCollision checks that no pod is assigned the gateway
+// address of its node, i.e. the first usable address of the node's K8s Pod
+// CIDR. A pod holding the gateway address usually indicates an off-by-one
+// error in the IP address management.
+func (v *Validator) ValidatePodGatewayCollision() {
+	errCnt := 0
+
+	for _, k8sNode := range v.K8sCache.RetrieveAllK8sNodes() {
+		_, podNet, err := net.ParseCIDR(k8sNode.Pod_CIDR)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("invalid Pod_CIDR %s", k8sNode.Pod_CIDR)
+			v.Report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+			continue
+		}
+		gateway := nextIP(podNet.IP)
+
+		for _, adr := range k8sNode.Addresses {
+			if adr.Type != nodemodel.NodeAddress_NodeInternalIP {
+				continue
+			}
+			for _, pod := range v.K8sCache.RetrievePodsByHostIPAddr(adr.Address) {
+				if !gateway.Equal(net.ParseIP(pod.IPAddress)) {
+					continue
+				}
+				errCnt++
+				errString := fmt.Sprintf("pod %s (namespace %s) IP address %s collides with the node's "+
+					"gateway address %s (Pod CIDR %s)", pod.Name, pod.Namespace, pod.IPAddress, gateway,
+					k8sNode.Pod_CIDR)
+				v.Report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryPods, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "Pod gateway collision")
+}
+
+// ValidateManagementSubnet checks that the management IP address of each
+// node belongs to the specified management subnet. A node with a management
+// IP address outside of the subnet is likely configured onto the wrong
+// management network. Both IPv4 and IPv6 subnets are supported; addresses
+// of the other address family are reported as family mismatches.
+func (v *Validator) ValidateManagementSubnet(subnet string) {
+	errCnt := 0
+
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		errCnt++
+		errString := fmt.Sprintf("invalid management subnet %s - skipping management subnet validation", subnet)
+		v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryNodes, errString)
+		v.addSummary(errCnt, "Management subnet")
+		return
+	}
+
+	for _, node := range v.VppCache.RetrieveAllNodes() {
+		if node.ManIPAddr == "" {
+			errCnt++
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, "management IP not set")
+			continue
+		}
+
+		ip := net.ParseIP(node.ManIPAddr)
+		if ip != nil && ipFamilyMismatch(ip, ipNet) {
+			errCnt++
+			errString := fmt.Sprintf("management IP address family mismatch: %s address %s, %s subnet %s",
+				ipFamily(ip), node.ManIPAddr, netFamily(ipNet), ipNet.String())
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, errString)
+			continue
+		}
+		if ip == nil || !ipNet.Contains(ip) {
+			errCnt++
+			errString := fmt.Sprintf("management IP address %s is not in the management subnet %s",
+				node.ManIPAddr, ipNet.String())
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Management subnet")
+}
+
+// ValidateL2FibOutgoingType checks that the outgoing interface of each
+// L2FIB entry in the vxlanBD is either a VXLAN tunnel (entries for remote
+// MAC addresses) or the bridge domain's BVI (the entry for the local MAC
+// address). Entries pointing at any other interface indicate corrupt FIB
+// programming.
+func (v *Validator) ValidateL2FibOutgoingType() {
+	v.runNodeChecks(l2FibOutgoingTypeCheck)
+}
+
+// checkL2FibOutgoingType performs ValidateL2FibOutgoingType for the nodes in nodeList.
+func (v *Validator) checkL2FibOutgoingType(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		vxLanBD, err := getVxlanBD(node)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("%s - skipping L2Fib outgoing interface validation for node %s",
+				err.Error(), node.Name)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
+			continue
+		}
+
+		bviNames := make(map[string]bool)
+		for _, bdIntf := range node.NodeBridgeDomains[vxLanBD].Bd.Interfaces {
+			if bdIntf.BVI {
+				bviNames[bdIntf.Name] = true
+			}
+		}
+
+		for _, feVal := range node.NodeL2Fibs {
+			if int(feVal.FeMeta.BridgeDomainID) != vxLanBD {
+				// Skip over entries in other BDs
+				continue
+			}
+
+			intf, ok := node.NodeInterfaces[int(feVal.FeMeta.OutgoingIfIndex)]
+			if !ok {
+				errCnt++
+				errString := fmt.Sprintf("L2Fib entry for MAC %s: outgoing interface ifIndex %d not found",
+					feVal.Fe.PhysAddress, feVal.FeMeta.OutgoingIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
+				continue
+			}
+
+			if intf.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL || bviNames[intf.If.Name] {
+				continue
+			}
+			errCnt++
+			errString := fmt.Sprintf("L2Fib entry for MAC %s points to interface %s (ifIndex %d) of type %s, "+
+				"expected a VXLAN tunnel or the BVI", feVal.Fe.PhysAddress, intf.If.Name,
+				feVal.FeMeta.OutgoingIfIndex, intf.If.IfType)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateUplinkPresence checks that each node has at least one enabled
+// GigabitEthernet (uplink) interface with an IP address. Without it, the
+// node cannot reach the underlay network. Nodes without any uplink are
+// reported separately from nodes whose uplinks are down or unaddressed.
+func (v *Validator) ValidateUplinkPresence() {
+	v.runNodeChecks(uplinkPresenceCheck)
+}
+
+// checkUplinkPresence performs ValidateUplinkPresence for the nodes in nodeList.
+func (v *Validator) checkUplinkPresence(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		uplinks := make([]string, 0)
+		uplinkUp := false
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_ETHERNET_CSMACD {
+				continue
+			}
+			uplinks = append(uplinks, intf.If.Name)
+			if intf.If.Enabled && len(intf.If.IPAddresses) > 0 {
+				uplinkUp = true
+			}
+		}
+		sort.Strings(uplinks)
+
+		switch {
+		case len(uplinks) == 0:
+			errCnt++
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces,
+				"no uplink: no GigabitEthernet interface found")
+		case !uplinkUp:
+			errCnt++
+			errString := fmt.Sprintf("uplink down/no IP: GigabitEthernet interfaces %v are disabled "+
+				"or have no IP address", uplinks)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateBdInterfaceNameIndexMatch checks that the interfaces listed by
+// name in each bridge domain agree with the bridge domain's sw_if_index to
+// name mapping. Each BD interface name is resolved to its sw_if_index via
+// the node's interface map; the mapping must have the same name for that
+// index. Bridge domains without the index mapping are skipped.
+func (v *Validator) ValidateBdInterfaceNameIndexMatch() {
+	v.runNodeChecks(bdInterfaceNameIndexMatchCheck)
+}
+
+// checkBdInterfaceNameIndexMatch performs ValidateBdInterfaceNameIndexMatch for the nodes in nodeList.
+func (v *Validator) checkBdInterfaceNameIndexMatch(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		ifName2Idx := make(map[string]uint32)
+		for ifIdx, intf := range node.NodeInterfaces {
+			ifName2Idx[intf.If.Name] = uint32(ifIdx)
+		}
+
+		for _, bd := range node.NodeBridgeDomains {
+			if len(bd.BdMeta.BdID2Name) == 0 {
+				continue
+			}
+
+			for _, bdIfc := range bd.Bd.Interfaces {
+				ifIdx, ok := ifName2Idx[bdIfc.Name]
+				if !ok {
+					errCnt++
+					errString := fmt.Sprintf("bridge domain %s: interface %s not found in interface map",
+						bd.Bd.Name, bdIfc.Name)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
+					continue
+				}
+
+				if name, ok := bd.BdMeta.BdID2Name[ifIdx]; !ok || name != bdIfc.Name {
+					errCnt++
+					errString := fmt.Sprintf("bridge domain %s: interface %s resolves to sw_if_index %d, "+
+						"but sw_if_index %d maps to '%s'", bd.Bd.Name, bdIfc.Name, ifIdx, ifIdx, name)
+					report.AppendToNodeReportWithCategory(node.Name, api.CategoryBridgeDomain, errString)
+				}
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateTunnelCardinality checks that each node has exactly one VXLAN
+// tunnel to each of the other nodes in the cluster, i.e. N-1 tunnels in a
+// full mesh of N nodes. Nodes with too few tunnels are missing a peer,
+// nodes with too many tunnels have leftover tunnels.
+func (v *Validator) ValidateTunnelCardinality() {
+	v.runNodeChecks(tunnelCardinalityCheck(len(v.VppCache.RetrieveAllNodes()) - 1))
+}
+
+// checkTunnelCardinality performs ValidateTunnelCardinality for the nodes in
+// nodeList, each of which is expected to have the expected number of tunnels.
+func (v *Validator) checkTunnelCardinality(expected int, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		tunnelCnt := 0
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL {
+				tunnelCnt++
+			}
+		}
+
+		switch {
+		case tunnelCnt < expected:
+			errCnt++
+			errString := fmt.Sprintf("too few VXLAN tunnels (missing peer): expected %d, got %d",
+				expected, tunnelCnt)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+		case tunnelCnt > expected:
+			errCnt++
+			errString := fmt.Sprintf("too many VXLAN tunnels (leftover): expected %d, got %d",
+				expected, tunnelCnt)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateAllowedNamespaces checks that all pods in the K8s cache are in
+// one of the allowed namespaces. Pods in other namespaces are reported on
+// the node that hosts them.
+func (v *Validator) ValidateAllowedNamespaces(allowed []string) {
+	errCnt := 0
+
+	allowedNamespaces := make(map[string]bool)
+	for _, ns := range allowed {
+		allowedNamespaces[ns] = true
+	}
+
+	for _, pod := range v.K8sCache.RetrieveAllPods() {
+		if allowedNamespaces[pod.Namespace] {
+			continue
+		}
+
+		reportNode := api.GlobalMsg
+		if node, err := v.VppCache.RetrieveNodeByHostIPAddr(pod.HostIPAddress); err == nil {
+			reportNode = node.Name
+		}
+
+		errCnt++
+		errString := fmt.Sprintf("pod %s is in namespace %s, which is not in the allowed namespaces %v",
+			pod.Name, pod.Namespace, sortedCopy(allowed))
+		v.Report.AppendToNodeReportWithCategory(reportNode, api.CategoryPods, errString)
+	}
+
+	v.addSummary(errCnt, "Pod namespace")
+}
+
+// ValidateMacAddressFormat makes sure that all MAC addresses collected from
+// node agents are well-formed: interface, ARP and L2Fib MAC addresses must
+// be parseable by net.ParseMAC. Interfaces without a MAC address (e.g.
+// VXLAN tunnels) are skipped.
+func (v *Validator) ValidateMacAddressFormat() {
+	v.runNodeChecks(macAddressFormatCheck)
+}
+
+// checkMacAddressFormat performs ValidateMacAddressFormat for the nodes in nodeList.
+func (v *Validator) checkMacAddressFormat(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.PhysAddress == "" {
+				continue
+			}
+			if _, err := net.ParseMAC(intf.If.PhysAddress); err != nil {
+				errCnt++
+				errString := fmt.Sprintf("malformed MAC address '%s' in phys_address of interface %s (ifIndex %d)",
+					intf.If.PhysAddress, intf.If.Name, intf.IfMeta.SwIfIndex)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+			}
+		}
+
+		for _, arpTableEntry := range node.NodeIPArp {
+			if _, err := net.ParseMAC(arpTableEntry.Ae.PhysAddress); err != nil {
+				errCnt++
+				errString := fmt.Sprintf("malformed MAC address '%s' in phys_address of ARP entry for %s "+
+					"on interface %s", arpTableEntry.Ae.PhysAddress, arpTableEntry.Ae.IPAddress,
+					arpTableEntry.Ae.Interface)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryArp, errString)
+			}
+		}
+
+		for _, fibEntry := range node.NodeL2Fibs {
+			if _, err := net.ParseMAC(fibEntry.Fe.PhysAddress); err != nil {
+				errCnt++
+				errString := fmt.Sprintf("malformed MAC address '%s' in phys_address of L2Fib entry "+
+					"in bridge domain %s", fibEntry.Fe.PhysAddress, fibEntry.Fe.BridgeDomainName)
+				report.AppendToNodeReportWithCategory(node.Name, api.CategoryL2Fib, errString)
+			}
+		}
+	}
+
+	return errCnt
+}
+
+// ValidatePodCidrDisjointness checks that the pod CIDRs of K8s nodes do not
+// overlap. Each overlapping pair of nodes is reported once, on the node that
+// comes first in the node list, along with the overlapping range.
+func (v *Validator) ValidatePodCidrDisjointness() {
+	errCnt := 0
+	k8sNodeList := v.K8sCache.RetrieveAllK8sNodes()
+
+	podNets := make([]*net.IPNet, len(k8sNodeList))
+	for i, k8sNode := range k8sNodeList {
+		if k8sNode.Pod_CIDR == "" {
+			continue
+		}
+		_, podNet, err := net.ParseCIDR(k8sNode.Pod_CIDR)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("invalid pod CIDR %s: %s", k8sNode.Pod_CIDR, err)
+			v.Report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryNodes, errString)
+			continue
+		}
+		podNets[i] = podNet
+	}
+
+	for i := range k8sNodeList {
+		for j := i + 1; j < len(k8sNodeList); j++ {
+			p1, p2 := podNets[i], podNets[j]
+			if p1 == nil || p2 == nil || !(p1.Contains(p2.IP) || p2.Contains(p1.IP)) {
+				continue
+			}
+
+			// The overlapping range is the longer of the two prefixes
+			overlap := p1
+			ones1, _ := p1.Mask.Size()
+			ones2, _ := p2.Mask.Size()
+			if ones2 > ones1 {
+				overlap = p2
+			}
+
+			errCnt++
+			errString := fmt.Sprintf("pod CIDR %s overlaps pod CIDR %s of node %s in range %s",
+				p1, p2, k8sNodeList[j].Name, overlap)
+			v.Report.AppendToNodeReportWithCategory(k8sNodeList[i].Name, api.CategoryNodes, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Pod CIDR disjointness")
+}
+
+// ValidateInterfaceVsLivenessState cross-checks the liveness state of each
+// node with the state of its interfaces: a node whose agent reports that it
+// is not running should not have any enabled interfaces. Nodes reporting
+// enabled interfaces while not running are flagged as suspect.
+func (v *Validator) ValidateInterfaceVsLivenessState() {
+	v.runNodeChecks(interfaceVsLivenessStateCheck)
+}
+
+// checkInterfaceVsLivenessState performs ValidateInterfaceVsLivenessState for the nodes in nodeList.
+func (v *Validator) checkInterfaceVsLivenessState(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		if node.NodeLiveness == nil || node.NodeLiveness.State == livenessStateOK {
+			continue
+		}
+
+		enabledCnt := 0
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.Enabled {
+				enabledCnt++
+			}
+		}
+		if enabledCnt == 0 {
+			continue
+		}
+
+		errCnt++
+		errString := fmt.Sprintf("suspect interface data: liveness state is %d (not running), "+
+			"but %d interfaces are enabled", node.NodeLiveness.State, enabledCnt)
+		report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+	}
+
+	return errCnt
+}
+
+// ValidateGlobalInvariants records the cluster-wide invariants of the
+// topology into the global report bin. It always emits the same
+// numGlobalInvariantMessages messages, in this order: the cluster size
+// (the number of VPP nodes and of K8s nodes, which must be equal), the
+// VXLAN mesh completeness (the number of VXLAN tunnels present on all nodes
+// and the number expected in a full mesh, N*(N-1) for N nodes) and the
+// summary of the validation. The mesh completeness is informational only,
+// missing tunnels are reported per node by ValidateTunnelCardinality.
+// Validate() records them after the summaries of all other validations.
+func (v *Validator) ValidateGlobalInvariants() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	numK8sNodes := len(v.K8sCache.RetrieveAllK8sNodes())
+	severity := api.SeverityInfo
+	if len(nodeList) != numK8sNodes {
+		errCnt++
+		severity = api.SeverityError
+	}
+	v.Report.Append(api.GlobalMsg, severity, api.CategoryNodes,
+		fmt.Sprintf("cluster size: %d VPP nodes, %d K8s nodes", len(nodeList), numK8sNodes))
+
+	numTunnels := 0
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL {
+				numTunnels++
+			}
+		}
+	}
+	expectedTunnels := len(nodeList) * (len(nodeList) - 1)
+	v.Report.Append(api.GlobalMsg, api.SeverityInfo, api.CategoryVxlan,
+		fmt.Sprintf("VXLAN mesh: %d of %d tunnels present", numTunnels, expectedTunnels))
+
+	v.addSummary(errCnt, "Global invariants")
+}
+
+// ValidateTunnelsEnabled checks that all VXLAN tunnel interfaces are
+// enabled. A disabled tunnel silently drops the overlay traffic to its peer.
+func (v *Validator) ValidateTunnelsEnabled() {
+	v.runNodeChecks(tunnelsEnabledCheck)
+}
+
+// checkTunnelsEnabled performs ValidateTunnelsEnabled for the nodes in nodeList.
+func (v *Validator) checkTunnelsEnabled(nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+	errCnt := 0
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL || intf.If.Enabled {
+				continue
+			}
+
+			peer := intf.If.Vxlan.DstAddress
+			if peerNode, err := v.VppCache.RetrieveNodeByGigEIPAddr(intf.If.Vxlan.DstAddress); err == nil {
+				peer = fmt.Sprintf("%s (node %s)", peer, peerNode.Name)
+			}
+
+			errCnt++
+			errString := fmt.Sprintf("VXLAN tunnel %s (ifIndex %d) to %s is disabled",
+				intf.If.Name, intf.IfMeta.SwIfIndex, peer)
+			report.AppendToNodeReportWithCategory(node.Name, api.CategoryVxlan, errString)
+		}
+	}
+
+	return errCnt
+}
+
+// ValidateUnderlaySubnetUniformity checks that the GigE interfaces of all
+// nodes are in the same subnet, as expected in a flat underlay. Nodes whose
+// GigE subnet differs from the cluster majority are reported; this is
+// typically a node plugged into the wrong underlay VLAN. Nodes without a
+// GigE IP address are ignored.
+func (v *Validator) ValidateUnderlaySubnetUniformity() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	nodeSubnets := make(map[string]string)
+	subnetCnt := make(map[string]int)
+	for _, node := range nodeList {
+	intfLoop:
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_ETHERNET_CSMACD {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				if _, subnet, err := net.ParseCIDR(ipAddr); err == nil {
+					nodeSubnets[node.Name] = subnet.String()
+					subnetCnt[subnet.String()]++
+					break intfLoop
+				}
+			}
+		}
+	}
+
+	if len(subnetCnt) > 1 {
+		expected := ""
+		for subnet, cnt := range subnetCnt {
+			if cnt > subnetCnt[expected] || (cnt == subnetCnt[expected] && subnet < expected) {
+				expected = subnet
+			}
+		}
+
+		for _, node := range nodeList {
+			subnet, ok := nodeSubnets[node.Name]
+			if !ok || subnet == expected {
+				continue
+			}
+			errCnt++
+			errString := fmt.Sprintf("GigE subnet %s differs from the underlay subnet %s of the cluster majority",
+				subnet, expected)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "Underlay subnet")
+}
+
+// ValidateTapAddressInPodCidr checks that the host addresses (/32 or /128)
+// of pod tap interfaces fall within the Pod CIDR of the hosting node's K8s
+// node counterpart. Host interconnect taps are skipped, as are nodes
+// missing in the K8s database (reported by ValidateK8sNodeInfo). Pod IP
+// addresses are allocated from the IPAM pod subnet, which need not be
+// aligned with the K8s Pod CIDR, so the check is not part of Validate().
+func (v *Validator) ValidateTapAddressInPodCidr() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	for _, node := range nodeList {
+		k8sNode, err := v.K8sCache.RetrieveK8sNode(node.Name)
+		if err != nil {
+			continue
+		}
+		_, podNet, err := net.ParseCIDR(k8sNode.Pod_CIDR)
+		if err != nil {
+			errCnt++
+			errString := fmt.Sprintf("invalid Pod_CIDR %s", k8sNode.Pod_CIDR)
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryPods, errString)
+			continue
+		}
+
+		for _, intf := range node.NodeInterfaces {
+			if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE || intf.IfMeta.Tag == hostTapTag {
+				continue
+			}
+			for _, ipAddr := range intf.If.IPAddresses {
+				ip, ipNet, err := net.ParseCIDR(ipAddr)
+				if err != nil {
+					continue
+				}
+				if ones, bits := ipNet.Mask.Size(); ones != bits || podNet.Contains(ip) {
+					continue
+				}
+				errCnt++
+				errString := fmt.Sprintf("pod tap %s (ifIndex %d) IP address %s is outside the node's Pod CIDR %s",
+					intf.If.Name, intf.IfMeta.SwIfIndex, ipAddr, k8sNode.Pod_CIDR)
+				v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryPods, errString)
+			}
+		}
+	}
+
+	v.addSummary(errCnt, "Tap address Pod CIDR")
+}
+
+// ValidateMtuBounds checks that the MTU of each interface is within the
+// valid bounds for IP, i.e. between the IP minimum and the hardware
+// maximum. Out-of-bounds MTUs usually come from bad telemetry. Interfaces
+// with MTU 0 are reported as having an unset MTU; since agents do not
+// report the MTU of all interface types (e.g. VXLAN tunnels), the check is
+// not part of Validate().
+func (v *Validator) ValidateMtuBounds() {
+	errCnt := 0
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	for _, node := range nodeList {
+		for _, intf := range node.NodeInterfaces {
+			var errString string
+			switch {
+			case intf.If.Mtu == 0:
+				errString = fmt.Sprintf("interface %s (ifIndex %d) MTU is unset",
+					intf.If.Name, intf.IfMeta.SwIfIndex)
+			case intf.If.Mtu < minIPMtu || intf.If.Mtu > maxHardwareMtu:
+				errString = fmt.Sprintf("interface %s (ifIndex %d) MTU %d is out of bounds [%d, %d]",
+					intf.If.Name, intf.IfMeta.SwIfIndex, intf.If.Mtu, minIPMtu, maxHardwareMtu)
+			default:
+				continue
+			}
+			errCnt++
+			v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryInterfaces, errString)
+		}
+	}
+
+	v.addSummary(errCnt, "MTU bounds")
+}
+
+// ValidateCollectedVsKnownNodes compares the set of nodes whose data was
+// successfully collected, i.e. whose liveness, interfaces, BDs, L2FIBs and
+// ARPs have all been received, with the set of nodes known to K8s.
+// K8s nodes that were not collected and collected nodes unknown to K8s are
+// counted and summarized separately. K8s nodes absent from the VPP node
+// cache are reported by ValidateK8sNodeInfo and skipped here.
+func (v *Validator) ValidateCollectedVsKnownNodes() {
+	uncollectedCnt := 0
+	unknownCnt := 0
+
+	k8sNodeMap := make(map[string]bool)
+	for _, k8sNode := range v.K8sCache.RetrieveAllK8sNodes() {
+		k8sNodeMap[k8sNode.Name] = true
+
+		node, err := v.VppCache.RetrieveNode(k8sNode.Name)
+		if err != nil {
+			continue
+		}
+		if missing := datastore.MissingNodeData(node); len(missing) > 0 {
+			uncollectedCnt++
+			errString := fmt.Sprintf("K8s node %s was not collected: missing %s",
+				k8sNode.Name, strings.Join(missing, ", "))
+			v.Report.AppendToNodeReportWithCategory(k8sNode.Name, api.CategoryNodes, errString)
+		}
+	}
+
+	for _, node := range v.VppCache.RetrieveAllNodes() {
+		if k8sNodeMap[node.Name] || len(datastore.MissingNodeData(node)) > 0 {
+			continue
+		}
+		unknownCnt++
+		errString := fmt.Sprintf("collected node %s is not known to K8s", node.Name)
+		v.Report.AppendToNodeReportWithCategory(node.Name, api.CategoryNodes, errString)
+	}
+
+	v.addSummary(uncollectedCnt, "K8s node collection")
+	v.addSummary(unknownCnt, "Collected node K8s membership")
+}
+
+func (v *Validator) createTapMarkAndSweepDB() {
+
+}
+
+// addSummary records the result of the validation of the kind and adds
+// its summary to the global section of the report.
+func (v *Validator) addSummary(errCnt int, kind string) {
+	v.results = append(v.results, api.ValidationResult{Name: kind, ErrorCount: errCnt})
+	if errCnt == 0 {
+		v.Report.Append(api.GlobalMsg, api.SeverityInfo, api.CategorySummary,
+			fmt.Sprintf("%s validation: OK", kind))
+	} else {
+		v.Report.Append(api.GlobalMsg, api.SeverityError, api.CategorySummary,
+			fmt.Sprintf("%s validation: %d error%s found", kind, errCnt, printS(errCnt)))
+	}
+}
+
+// Results returns the results of the validations performed by the
+// Validator since the last call to Validate(), in the order in which they
+// were performed.
+func (v *Validator) Results() []api.ValidationResult {
+	results := make([]api.ValidationResult, len(v.results))
+	copy(results, v.results)
+	return results
+}
+
+func getVxlanBD(node *telemetrymodel.Node) (int, error) {
+	for bdomainIdx, bdomain := range node.NodeBridgeDomains {
+		if bdomain.Bd.Name == "vxlanBD" {
+			return bdomainIdx, nil
+		}
+	}
+	return 0, fmt.Errorf("vxlanBD not found")
+}
+
+// getVxlanTunnelPeers returns the set of nodes that the specified node has
+// VXLAN tunnels to. The remote node of a tunnel is resolved from the
+// tunnel's destination address; tunnels whose destination address does not
+// resolve to a known node are ignored (they are reported by the BD
+// validation).
+func (v *Validator) getVxlanTunnelPeers(node *telemetrymodel.Node) map[string]bool {
+	peers := make(map[string]bool)
+	for _, intf := range node.NodeInterfaces {
+		if intf.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+			continue
+		}
+		if dstNode, err := v.VppCache.RetrieveNodeByGigEIPAddr(intf.If.Vxlan.DstAddress); err == nil {
+			peers[dstNode.Name] = true
+		}
+	}
+	return peers
+}
+
+// isExpectedExtraInterface returns true if the interface with the specified
+// name is in the ExpectedExtraInterfaces allowlist, either by its exact name
+// or by a regular expression matching the whole name.
+func (v *Validator) isExpectedExtraInterface(ifName string) bool {
+	for _, entry := range v.ExpectedExtraInterfaces {
+		if entry == ifName {
+			return true
+		}
+		if matched, err := regexp.MatchString("^(?:"+entry+")$", ifName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// podHasLabel returns true if the pod has the label with the specified key
+// and value.
+func podHasLabel(pod *telemetrymodel.Pod, key string, value string) bool {
+	for _, label := range pod.Label {
+		if label.Key == key && label.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPodTap returns true if the node has a tap interface for the pod with
+// the specified IP address in the pod network podNet. The tap interface IP
+// address is in the node's PodIfIPCIDR and has the same host part as the
+// pod IP address.
+func hasPodTap(node *telemetrymodel.Node, podIP net.IP, podNet *net.IPNet) bool {
+	_, podIfNet, err := net.ParseCIDR(node.NodeIPam.Config.PodIfIPCIDR)
+	if err != nil {
+		return false
+	}
+	podHost := hostBits(podIP, podNet)
+
+	for _, intf := range node.NodeInterfaces {
+		if intf.If.IfType != interfaces.InterfaceType_TAP_INTERFACE {
+			continue
+		}
+		for _, ipAddr := range intf.If.IPAddresses {
+			tapIP, _, err := net.ParseCIDR(ipAddr)
+			if err != nil || !podIfNet.Contains(tapIP) {
+				continue
+			}
+			if hostBits(tapIP, podIfNet).Equal(podHost) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextIP returns the IP address following the specified IP address.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// ipFamilyMismatch returns true if the IP address and the network are not
+// of the same address family (IPv4 vs. IPv6).
+func ipFamilyMismatch(ip net.IP, network *net.IPNet) bool {
+	return (ip.To4() != nil) != (len(network.Mask) == net.IPv4len)
+}
+
+// ipFamily returns the name of the address family of the IP address.
+func ipFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// netFamily returns the name of the address family of the network.
+func netFamily(network *net.IPNet) string {
+	if len(network.Mask) == net.IPv4len {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// hostBits returns the host part of the IP address in the network, i.e.
+// the address with the network prefix bits cleared. The IP address and the
+// network must be of the same address family.
+func hostBits(ip net.IP, network *net.IPNet) net.IP {
+	if len(network.Mask) == net.IPv4len {
+		ip = ip.To4()
+	} else {
+		ip = ip.To16()
+	}
+	host := make(net.IP, len(ip))
+	for i := range ip {
+		host[i] = ip[i] &^ network.Mask[i]
+	}
+	return host
+}
+
+// subnetIndex returns the subnet index encoded in the IP address, i.e. the
+// 8 (IPv4) or 16 (IPv6) bits of the network prefix immediately preceding
+// the host part of the address. ok is false if the prefix is shorter than
+// the subnet index.
+func subnetIndex(ip net.IP, network *net.IPNet) (index uint32, ok bool) {
+	ones, bits := network.Mask.Size()
+	width := 16
+	if bits == 8*net.IPv4len {
+		ip = ip.To4()
+		width = 8
+	} else {
+		ip = ip.To16()
+	}
+	if ones < width {
+		return 0, false
+	}
+	for i := ones - width; i < ones; i++ {
+		index = index<<1 | uint32(ip[i/8]>>uint(7-i%8)&1)
+	}
+	return index, true
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func printS(errCnt int) string {
+	if errCnt > 1 {
+		return "s"
+	}
+	return ""
+}
+
+func sortedCopy(in []string) []string {
+	out := make([]string, len(in))
+	copy(out, in)
+	sort.Strings(out)
+	return out
 }
@@ -95,7 +95,7 @@ func TestValidator(t *testing.T) {
 
 	vtv.vppCache = datastore.NewVppDataStore()
 	vtv.k8sCache = datastore.NewK8sDataStore()
-	vtv.report = datastore.NewSimpleReport(vtv.log)
+	vtv.report = datastore.NewSimpleReport(vtv.log, 0)
 
 	// Initialize the validator
 	vtv.l2Validator = &Validator{
@@ -114,6 +114,57 @@ func TestValidator(t *testing.T) {
 	t.Run("testValidateL2FibEntries", testValidateL2FibEntries)
 	t.Run("testValidateArpEntries", testValidateArpEntries)
 	t.Run("testValidatePodInfo", testValidatePodInfo)
+	t.Run("testValidateIPAddressFormat", testValidateIPAddressFormat)
+	t.Run("testValidatePodHostBinding", testValidatePodHostBinding)
+	t.Run("testValidateDisabledInterfaceState", testValidateDisabledInterfaceState)
+	t.Run("testValidateBridgeDomainCount", testValidateBridgeDomainCount)
+	t.Run("testValidateVxlanMtuHeadroom", testValidateVxlanMtuHeadroom)
+	t.Run("testValidateHubSpokeVxlan", testValidateHubSpokeVxlan)
+	t.Run("testValidateBviIpEncodesNodeId", testValidateBviIpEncodesNodeId)
+	t.Run("testValidateLoopbackCount", testValidateLoopbackCount)
+	t.Run("testValidateL2FibStaticness", testValidateL2FibStaticness)
+	t.Run("testValidateRequiredPodLabels", testValidateRequiredPodLabels)
+	t.Run("testValidateIpPrefixDisjointness", testValidateIpPrefixDisjointness)
+	t.Run("testValidateSplitHorizonGroups", testValidateSplitHorizonGroups)
+	t.Run("testValidateVxlanUnderlayReachability", testValidateVxlanUnderlayReachability)
+	t.Run("testValidateInterfaceIndexConsistency", testValidateInterfaceIndexConsistency)
+	t.Run("testValidateManagementIpMatch", testValidateManagementIpMatch)
+	t.Run("testValidateAgainstExpected", testValidateAgainstExpected)
+	t.Run("testValidateEtcdPlacement", testValidateEtcdPlacement)
+	t.Run("testValidateTelemetryCounters", testValidateTelemetryCounters)
+	t.Run("testValidateJumboFrames", testValidateJumboFrames)
+	t.Run("testValidateBridgeDomainNaming", testValidateBridgeDomainNaming)
+	t.Run("testValidateLoopbackSubnetMembership", testValidateLoopbackSubnetMembership)
+	t.Run("testValidateGigEIpUniqueness", testValidateGigEIpUniqueness)
+	t.Run("testValidateTapToPodParity", testValidateTapToPodParity)
+	t.Run("testValidateOrphanedVxlanTunnels", testValidateOrphanedVxlanTunnels)
+	t.Run("testValidateHostTapAddressing", testValidateHostTapAddressing)
+	t.Run("testValidateDaemonSetGeneration", testValidateDaemonSetGeneration)
+	t.Run("testValidateMinimumNodeCount", testValidateMinimumNodeCount)
+	t.Run("testValidateConfiguredVni", testValidateConfiguredVni)
+	t.Run("testValidateDnsPodReachability", testValidateDnsPodReachability)
+	t.Run("testValidateVrfAssignment", testValidateVrfAssignment)
+	t.Run("testValidateTapVersionUniformity", testValidateTapVersionUniformity)
+	t.Run("testExpectedExtraInterfaces", testExpectedExtraInterfaces)
+	t.Run("testValidateBdHasBvi", testValidateBdHasBvi)
+	t.Run("testValidatePodGatewayCollision", testValidatePodGatewayCollision)
+	t.Run("testValidateManagementSubnet", testValidateManagementSubnet)
+	t.Run("testValidateL2FibOutgoingType", testValidateL2FibOutgoingType)
+	t.Run("testValidateUplinkPresence", testValidateUplinkPresence)
+	t.Run("testValidateBdInterfaceNameIndexMatch", testValidateBdInterfaceNameIndexMatch)
+	t.Run("testValidateTunnelCardinality", testValidateTunnelCardinality)
+	t.Run("testValidateAllowedNamespaces", testValidateAllowedNamespaces)
+	t.Run("testValidateMacAddressFormat", testValidateMacAddressFormat)
+	t.Run("testValidatePodCidrDisjointness", testValidatePodCidrDisjointness)
+	t.Run("testValidateInterfaceVsLivenessState", testValidateInterfaceVsLivenessState)
+	t.Run("testValidateGlobalInvariants", testValidateGlobalInvariants)
+	t.Run("testIPv6Addresses", testIPv6Addresses)
+	t.Run("testValidateTunnelsEnabled", testValidateTunnelsEnabled)
+	t.Run("testValidateUnderlaySubnetUniformity", testValidateUnderlaySubnetUniformity)
+	t.Run("testValidateTapAddressInPodCidr", testValidateTapAddressInPodCidr)
+	t.Run("testValidateMtuBounds", testValidateMtuBounds)
+	t.Run("testValidateCollectedVsKnownNodes", testValidateCollectedVsKnownNodes)
+	t.Run("testConcurrentValidation", testConcurrentValidation)
 
 }
 
@@ -122,7 +173,20 @@ func testErrorFreeTopologyValidation(t *testing.T) {
 
 	vtv.l2Validator.Validate()
 
-	gomega.Expect(len(vtv.report.Data[api.GlobalMsg])).To(gomega.Equal(5))
+	// The global messages are one summary per validation followed by the
+	// global invariants
+	globalMsgs := vtv.report.GlobalMessages()
+	gomega.Expect(globalMsgs).To(gomega.HaveLen(
+		len(vtv.l2Validator.Results()) + numGlobalInvariantMessages - 1))
+	numSummaries := len(globalMsgs) - numGlobalInvariantMessages
+	gomega.Expect(globalMsgs[numSummaries:]).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: api.GlobalMsg, Message: "cluster size: 3 VPP nodes, 3 K8s nodes"},
+		{NodeName: api.GlobalMsg, Message: "VXLAN mesh: 6 of 6 tunnels present"},
+		{NodeName: api.GlobalMsg, Message: "Global invariants validation: OK"},
+	}))
+	for _, entry := range globalMsgs[:numSummaries] {
+		gomega.Expect(entry.Message).To(gomega.MatchRegexp(`^.+ validation: OK$`))
+	}
 }
 
 func testK8sNodeToNodeInfoOkValidation(t *testing.T) {
@@ -631,6 +695,50 @@ func testValidateArpEntries(t *testing.T) {
 		vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp[i].Ae.PhysAddress = oldIPAddress
 		break
 	}
+
+	// ----------------------------------------------------------
+	// INJECT FAULT: Swapped MAC addresses in the BVI ARP entries
+	resetToInitialErrorFreeState()
+	arps := vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp
+	arps[0].Ae.PhysAddress, arps[1].Ae.PhysAddress = arps[1].Ae.PhysAddress, arps[0].Ae.PhysAddress
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateArpTables()
+
+	checkDataReport(1, 2, 0)
+	for _, line := range vtv.report.Data[vtv.nodeKey] {
+		gomega.Expect(line).To(gomega.ContainSubstring("whose BVI MAC address is"))
+	}
+
+	// ------------------------------------------------
+	// INJECT FAULT: ARP entry for a remote BVI removed
+	resetToInitialErrorFreeState()
+	peer, err := vtv.vppCache.RetrieveNode("k8s-worker1")
+	gomega.Expect(err).To(gomega.BeNil())
+	peerLoopIf, err := datastore.GetNodeLoopIFInfo(peer)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	arpTable := vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp
+	newArpTable := make(telemetrymodel.NodeIPArpTable, 0)
+	for _, arpTableEntry := range arpTable {
+		if arpTableEntry.Ae.PhysAddress != peerLoopIf.If.PhysAddress {
+			newArpTable = append(newArpTable, arpTableEntry)
+		}
+	}
+	gomega.Expect(newArpTable).To(gomega.HaveLen(len(arpTable) - 1))
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp = newArpTable
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateArpTables()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(
+		gomega.Equal("node k8s-master missing ARP for node k8s-worker1's BVI"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
 }
 
 func testValidatePodInfo(t *testing.T) {
@@ -643,11 +751,11 @@ func testValidatePodInfo(t *testing.T) {
 		oldHostIPAddress := pod.HostIPAddress
 		vtv.k8sCache.UpdatePod(pod.Name, pod.Namespace, pod.Label, pod.IPAddress, "1.2.3.4", nil)
 
-		// Perform test
+		// Perform test: the pod is left to ValidatePodHostBinding
 		vtv.report.Clear()
 		vtv.l2Validator.ValidatePodInfo()
 
-		checkDataReport(2, 0, 0)
+		checkDataReport(1, 0, 0)
 
 		// Restore data back to error free state
 		vtv.k8sCache.UpdatePod(pod.Name, pod.Namespace, pod.Label, pod.IPAddress, oldHostIPAddress, nil)
@@ -707,7 +815,8 @@ func testValidatePodInfo(t *testing.T) {
 	vtv.report.Clear()
 	vtv.l2Validator.ValidatePodInfo()
 
-	checkDataReport(1, podCnt, 0)
+	// All pods on the node are skipped, so the kube-dns pod's tap is dangling
+	checkDataReport(1, podCnt+1, 0)
 
 	// Restore data back to error free state
 	resetToInitialErrorFreeState()
@@ -764,6 +873,2393 @@ func testValidatePodInfo(t *testing.T) {
 	}
 }
 
+func testValidateIPAddressFormat(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIPAddressFormat()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------------
+	// INJECT FAULT: Malformed IP address on a node interface
+	k, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).To(gomega.Not(gomega.BeNil()))
+	ifp.If.IPAddresses = []string{"192,168.20.1/24"}
+	ifp.If.Vxlan.DstAddress = "192.168.16"
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIPAddressFormat()
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.CountByCategory()).To(gomega.Equal(map[string]int{
+		api.CategoryInterfaces: 1,
+		api.CategoryVxlan:      1,
+	}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+
+	// --------------------------------------------------
+	// INJECT FAULT: Malformed IP address in an ARP entry
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp[0].Ae.IPAddress = "192.168.30.2/24"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIPAddressFormat()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.CountByCategory()).To(gomega.Equal(map[string]int{api.CategoryArp: 1}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidatePodHostBinding(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodHostBinding()
+
+	checkDataReport(1, 0, 0)
+
+	// -----------------------------------------------
+	// INJECT FAULT: Pod bound to an unknown host IP
+	err := vtv.k8sCache.CreatePod("bogus-pod", "default", nil, "10.1.1.99", "10.20.0.99", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodHostBinding()
+
+	checkDataReport(2, 0, 0)
+	gomega.Expect(vtv.report.Data[api.GlobalMsg][0]).To(gomega.ContainSubstring("bogus-pod"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateDisabledInterfaceState(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDisabledInterfaceState()
+
+	checkDataReport(1, 0, 0)
+
+	// -------------------------------------------------------------------
+	// INJECT FAULT: Disabled interface with an IP address and an enabled
+	// interface without one
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		switch ifc.IfMeta.VppInternalName {
+		case "tap0":
+			ifc.If.Enabled = false
+		case "GigabitEthernet0/8/0":
+			ifc.If.IPAddresses = nil
+		}
+		vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDisabledInterfaceState()
+
+	checkDataReport(1, 2, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateBridgeDomainCount(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBridgeDomainCount(numBridgeDomains)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Leftover bridge domain on the node
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[2] = telemetrymodel.NodeBridgeDomain{
+		Bd: telemetrymodel.BridgeDomain{
+			Name: "leftoverBD",
+		},
+		BdMeta: telemetrymodel.BridgeDomainMeta{
+			BdID: 2,
+		},
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBridgeDomainCount(numBridgeDomains)
+
+	checkDataReport(1, 1, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateVxlanMtuHeadroom(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanMtuHeadroom()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: GigE MTU too small for the overlay MTU plus VXLAN overhead
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.Mtu = 1480
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+	tapCnt := 0
+	for _, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_TAP_INTERFACE && ifc.If.Mtu != 0 {
+			tapCnt++
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanMtuHeadroom()
+
+	checkDataReport(1, tapCnt, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Overridden VXLAN overhead makes the default GigE MTU
+	// insufficient
+	resetToInitialErrorFreeState()
+	vtv.l2Validator.VxlanOverhead = 9000
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanMtuHeadroom()
+
+	gomega.Expect(len(vtv.report.Data[api.GlobalMsg])).To(gomega.Equal(1))
+	for _, node := range vtv.vppCache.RetrieveAllNodes() {
+		nodeTapCnt := 0
+		for _, ifc := range node.NodeInterfaces {
+			if ifc.If.IfType == interfaces.InterfaceType_TAP_INTERFACE && ifc.If.Mtu != 0 {
+				nodeTapCnt++
+			}
+		}
+		gomega.Expect(len(vtv.report.Data[node.Name])).To(gomega.Equal(nodeTapCnt))
+	}
+
+	// Restore data back to error free state
+	vtv.l2Validator.VxlanOverhead = 0
+	resetToInitialErrorFreeState()
+}
+
+func testValidateHubSpokeVxlan(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHubSpokeVxlan("k8s-master")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Tunnel between workers is missing; this is allowed in
+	// a hub-spoke topology
+	ifIdx := vtv.findVxlanInterfaceTo("k8s-worker1", "k8s-worker2")
+	gomega.Expect(ifIdx).NotTo(gomega.Equal(-1))
+	delete(vtv.vppCache.NodeMap["k8s-worker1"].NodeInterfaces, ifIdx)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHubSpokeVxlan("k8s-master")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Master is missing the tunnel to a worker
+	resetToInitialErrorFreeState()
+	ifIdx = vtv.findVxlanInterfaceTo("k8s-master", "k8s-worker1")
+	gomega.Expect(ifIdx).NotTo(gomega.Equal(-1))
+	delete(vtv.vppCache.NodeMap["k8s-master"].NodeInterfaces, ifIdx)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHubSpokeVxlan("k8s-master")
+
+	checkDataReport(1, 1, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Worker is missing the tunnel to the master
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+	ifIdx = vtv.findVxlanInterfaceTo("k8s-worker2", "k8s-master")
+	gomega.Expect(ifIdx).NotTo(gomega.Equal(-1))
+	delete(vtv.vppCache.NodeMap["k8s-worker2"].NodeInterfaces, ifIdx)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHubSpokeVxlan("k8s-master")
+
+	checkDataReport(1, 1, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Unknown master node
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHubSpokeVxlan("no-such-node")
+
+	checkDataReport(2, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateBviIpEncodesNodeId(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBviIpEncodesNodeId()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: BVI IP address host octet does not match the node ID
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.VppInternalName == "loop0" {
+			ifc.If.IPAddresses = []string{"192.168.30.5/24"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBviIpEncodesNodeId()
+
+	checkDataReport(1, 1, 0)
+
+	// The check is opt-in: Validate() performs it only if enabled
+	vtv.report.Clear()
+	vtv.l2Validator.Validate()
+	numResults := len(vtv.l2Validator.Results())
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(
+		numResults + numGlobalInvariantMessages - 1))
+
+	vtv.l2Validator.BviIPEncodesNodeID = true
+	vtv.report.Clear()
+	vtv.l2Validator.Validate()
+	gomega.Expect(vtv.l2Validator.Results()).To(gomega.HaveLen(numResults + 1))
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(
+		numResults + numGlobalInvariantMessages))
+
+	// Restore data back to error free state
+	vtv.l2Validator.BviIPEncodesNodeID = false
+	resetToInitialErrorFreeState()
+}
+
+func testValidateLoopbackCount(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Leftover loopback interface on the node
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[100] = telemetrymodel.NodeInterface{
+		If: telemetrymodel.Interface{
+			Name:    "leftoverLoop",
+			IfType:  interfaces.InterfaceType_SOFTWARE_LOOPBACK,
+			Enabled: true,
+		},
+		IfMeta: telemetrymodel.InterfaceMeta{
+			SwIfIndex:       100,
+			VppInternalName: "loop1",
+		},
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("[leftoverLoop vxlanBVI]"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: No loopback interface on the node
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.VppInternalName == "loop0" {
+			delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, k)
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 1, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateL2FibStaticness(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibStaticness()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Dynamically learned entry in the vxlanBD
+	var faultyMac string
+	for mac, fibEntry := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs {
+		fibEntry.Fe.StaticConfig = false
+		vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs[mac] = fibEntry
+		faultyMac = fibEntry.Fe.PhysAddress
+		break
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibStaticness()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(faultyMac))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("vxlanBD"))
+
+	// Non-static entries are allowed in BDs that are not configured as static
+	vtv.l2Validator.StaticFibBDs = []string{"otherBD"}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibStaticness()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	vtv.l2Validator.StaticFibBDs = nil
+	resetToInitialErrorFreeState()
+}
+
+func testValidateRequiredPodLabels(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	required := map[string][]string{"default": {"run", "pod-template-hash"}}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateRequiredPodLabels(required)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Typo in a required label key
+	pod, err := vtv.k8sCache.RetrievePod("nginx-768979984b-7lgkl", "default")
+	gomega.Expect(err).To(gomega.BeNil())
+	for _, label := range pod.Label {
+		if label.Key == "pod-template-hash" {
+			label.Key = "pod-template-bash"
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateRequiredPodLabels(required)
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("nginx-768979984b-7lgkl"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("missing label pod-template-hash"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("unexpected label pod-template-bash"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Static control plane pods do not have the k8s-app label
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateRequiredPodLabels(map[string][]string{"kube-system": {"k8s-app"}})
+
+	gomega.Expect(vtv.report.FilterReport("missing label k8s-app")).To(gomega.HaveLen(4))
+	gomega.Expect(vtv.report.FilterReport("unexpected label")).To(gomega.BeEmpty())
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateIpPrefixDisjointness(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// The shared BVI subnet is allowed even if it is in a disjoint class
+	classes := []string{"172.30.0.0/16", "192.168.30.0/24"}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIpPrefixDisjointness(classes)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Host network prefix on master overlaps with worker1
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.Name == "tap-vpp2" {
+			ifc.If.IPAddresses = []string{"172.30.2.1/24"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIpPrefixDisjointness(classes)
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).
+		To(gomega.Equal("prefix 172.30.2.0/24 overlaps prefix 172.30.2.0/24 on node k8s-worker1"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Invalid prefix class
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateIpPrefixDisjointness([]string{"172.30.0.0/33"})
+
+	checkDataReport(2, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateSplitHorizonGroups(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateSplitHorizonGroups()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: VXLAN tunnel with no split-horizon group
+	bdIdx, err := getVxlanBD(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	bd := vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	for i, bdIfc := range bd.Bd.Interfaces {
+		if !bdIfc.BVI {
+			bd.Bd.Interfaces[i].SplitHorizonGrp = 0
+			break
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateSplitHorizonGroups()
+
+	checkDataReport(1, 1, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Expected split-horizon group differs from the one
+	// configured on all tunnels
+	resetToInitialErrorFreeState()
+	vtv.l2Validator.SplitHorizonGroup = 2
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateSplitHorizonGroups()
+
+	checkDataReport(1, 2, 2)
+
+	// Restore data back to error free state
+	vtv.l2Validator.SplitHorizonGroup = 0
+	resetToInitialErrorFreeState()
+}
+
+func testValidateVxlanUnderlayReachability(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanUnderlayReachability()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Tunnel destination outside of the GigE subnet
+	ifIdx, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Vxlan.DstAddress = "10.99.0.1"
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanUnderlayReachability()
+
+	checkDataReport(1, 1, 0)
+
+	// Tunnel destination outside of the GigE subnet is OK if it is in the
+	// node's ARP table
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp = append(vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp,
+		telemetrymodel.NodeIPArpEntry{
+			Ae: telemetrymodel.IPArpEntry{
+				IPAddress:   "10.99.0.1",
+				PhysAddress: "08:00:27:00:00:01",
+			},
+		})
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVxlanUnderlayReachability()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateInterfaceIndexConsistency(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceIndexConsistency()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Interface stored under a wrong index
+	ifIdx, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.IfMeta.SwIfIndex = 1000
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceIndexConsistency()
+
+	checkDataReport(1, 1, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: ARP entry and L2Fib entry referencing a non-existent
+	// interface
+	resetToInitialErrorFreeState()
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp[0].AeMeta.IfIndex = 1000
+	for mac, fibEntry := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs {
+		fibEntry.FeMeta.OutgoingIfIndex = 1000
+		vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs[mac] = fibEntry
+		break
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceIndexConsistency()
+
+	checkDataReport(1, 2, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: BD interface referencing a non-existent interface
+	resetToInitialErrorFreeState()
+	bdIdx, err := getVxlanBD(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx].BdMeta.BdID2Name[1000] = "vxlan1000"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceIndexConsistency()
+
+	checkDataReport(1, 1, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateManagementIpMatch(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementIpMatch()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Management IP address not known to K8s
+	vtv.vppCache.NodeMap[vtv.nodeKey].ManIPAddr = "10.20.0.99"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementIpMatch()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("10.20.0.2"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateAgainstExpected(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	expected := make(map[string]telemetrymodel.NodeInterfaces)
+	for _, node := range vtv.vppCache.RetrieveAllNodes() {
+		expected[node.Name] = telemetrymodel.NodeInterfaces(node.NodeInterfaces).DeepCopy()
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAgainstExpected(expected)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Interface MTU, IP address and admin state differ from
+	// the expected config
+	ifIdx, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Mtu = 1234
+	ifp.If.IPAddresses = []string{"1.2.3.4/24"}
+	ifp.If.Enabled = !ifp.If.Enabled
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAgainstExpected(expected)
+
+	checkDataReport(1, 3, 0)
+	gomega.Expect(vtv.report.FilterReport("MTU mismatch")).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.FilterReport("IP address mismatch")).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.FilterReport("enabled mismatch")).To(gomega.HaveLen(1))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Interface missing in collected data and extra interface
+	// not in config
+	resetToInitialErrorFreeState()
+	intf := vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx]
+	intf.If.Name = "vxlan_tunnel_extra"
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx] = intf
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAgainstExpected(expected)
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.FilterReport("missing in collected data")).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.FilterReport("vxlan_tunnel_extra: present in collected data")).To(gomega.HaveLen(1))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Expected config for unknown node
+	resetToInitialErrorFreeState()
+	expected["k8s-unknown"] = expected[vtv.nodeKey]
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAgainstExpected(expected)
+
+	checkDataReport(2, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateEtcdPlacement(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateEtcdPlacement("k8s-master")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Etcd pod running on a worker node
+	label := []*podmodel.Pod_Label{{Key: "k8s-app", Value: "contiv-etcd"}}
+	err := vtv.k8sCache.CreatePod("contiv-etcd-1", "kube-system", label, "10.20.0.10", "10.20.0.10", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateEtcdPlacement("k8s-master")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("contiv-etcd-1"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Unknown master node
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateEtcdPlacement("k8s-unknown")
+
+	checkDataReport(2, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTelemetryCounters(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	thresholds := map[string]int{"ip4-input: ip4 drops": 100, "error-drop": 10}
+	telemetry := telemetrymodel.NodeTelemetry{
+		Command: "show node counters",
+		Output: []telemetrymodel.Output{
+			{
+				Command: "show node counters",
+				Output: []telemetrymodel.OutputEntry{
+					{NodeName: vtv.nodeKey, Count: 100, Reason: "ip4-input: ip4 drops"},
+					{NodeName: vtv.nodeKey, Count: 5, Reason: "error-drop"},
+					{NodeName: vtv.nodeKey, Count: 5000, Reason: "unknown-reason"},
+				},
+			},
+		},
+	}
+	err := vtv.vppCache.SetNodeTelemetry(vtv.nodeKey,
+		map[string]telemetrymodel.NodeTelemetry{telemetry.Command: telemetry})
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTelemetryCounters(thresholds)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Drop counter exceeds its threshold
+	telemetry.Output[0].Output[0].Count = 101
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTelemetryCounters(thresholds)
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("ip4 drops"))
+	gomega.Expect(vtv.report.CountByCategory()).To(gomega.Equal(map[string]int{api.CategoryTelemetry: 1}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateJumboFrames(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateJumboFrames(9000)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: GigE interface came up with the default MTU
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.Mtu = 1500
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateJumboFrames(9000)
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("MTU 1500"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateBridgeDomainNaming(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBridgeDomainNaming("")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Bridge domain with an unexpected name on one node
+	bdIdx, err := getVxlanBD(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	bd := vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	bd.Bd.Name = "overlayBD"
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx] = bd
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBridgeDomainNaming("")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("overlayBD"))
+
+	// Perform test: the renamed bridge domain is the only one matching an
+	// explicitly specified name
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBridgeDomainNaming("overlayBD")
+
+	checkDataReport(1, 0, 1)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateLoopbackSubnetMembership(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackSubnetMembership("192.168.30.0/24")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: BVI IP address from the wrong pool
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.VppInternalName == "loop0" {
+			ifc.If.IPAddresses = []string{"192.168.31.1/24"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackSubnetMembership("192.168.30.0/24")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("192.168.31.1/24"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: BVI IP address missing
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.VppInternalName == "loop0" {
+			ifc.If.IPAddresses = nil
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackSubnetMembership("192.168.30.0/24")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal("BVI address missing"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Invalid subnet
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackSubnetMembership("192.168.30.0")
+
+	checkDataReport(2, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateGigEIpUniqueness(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGigEIpUniqueness()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Two nodes with the same GigE IP address
+	var gigEIPs []string
+	for _, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			gigEIPs = ifc.If.IPAddresses
+		}
+	}
+	gomega.Expect(gigEIPs).NotTo(gomega.BeEmpty())
+	for k, ifc := range vtv.vppCache.NodeMap["k8s-worker1"].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.IPAddresses = gigEIPs
+			vtv.vppCache.NodeMap["k8s-worker1"].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGigEIpUniqueness()
+
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey]).To(gomega.HaveLen(len(gigEIPs)))
+	gomega.Expect(vtv.report.Data["k8s-worker1"]).To(gomega.HaveLen(len(gigEIPs)))
+	gomega.Expect(vtv.report.Data).NotTo(gomega.HaveKey("k8s-worker2"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("k8s-master, k8s-worker1"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTapToPodParity(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapToPodParity()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Pod without a tap interface
+	err := vtv.k8sCache.CreatePod("no-tap-pod", "default", nil, "10.1.1.9", "10.20.0.2", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapToPodParity()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("pod tap count 1, pod count 2"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Tap interface leaked after pod deletion
+	resetToInitialErrorFreeState()
+	for _, pod := range vtv.k8sCache.RetrievePodsByHostIPAddr("10.20.0.2") {
+		if pod.IPAddress != pod.HostIPAddress {
+			gomega.Expect(vtv.k8sCache.DeletePod(pod.Name, pod.Namespace)).To(gomega.Succeed())
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapToPodParity()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("pod tap count 1, pod count 0"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateOrphanedVxlanTunnels(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateOrphanedVxlanTunnels()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: VXLAN tunnel not attached to any bridge domain
+	_, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Name = "vxlan_orphan"
+	ifp.IfMeta.SwIfIndex = 99
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[99] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateOrphanedVxlanTunnels()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("vxlan_orphan (ifIndex 99)"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateHostTapAddressing(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHostTapAddressing()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Master host tap with the address of worker2's host tap
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.Tag == "tap-vpp2" {
+			ifc.If.IPAddresses = []string{"172.30.3.1/24"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHostTapAddressing()
+
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey]).To(gomega.HaveLen(2))
+	gomega.Expect(vtv.report.Data["k8s-worker2"]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data).NotTo(gomega.HaveKey("k8s-worker1"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("subnet index 3, expected node ID 1"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][1]).To(gomega.ContainSubstring(
+		"172.30.3.1, shared by nodes k8s-master, k8s-worker2"))
+	gomega.Expect(vtv.report.Data["k8s-worker2"][0]).To(gomega.ContainSubstring(
+		"172.30.3.1, shared by nodes k8s-master, k8s-worker2"))
+
+	// ------------------------------------------------------------------
+	// INJECT FAULT: IPv6 master host tap address with a wrong subnet index
+	// and an address with a prefix too short to encode a subnet index
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.Tag == "tap-vpp2" {
+			ifc.If.IPAddresses = []string{"fd30:0:0:1::1/64", "fd30:0:0:2::1/64", "fd31::1/8"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateHostTapAddressing()
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"host tap IPv6 address fd30:0:0:2::1 has subnet index 2, expected node ID 1"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][1]).To(gomega.ContainSubstring("fd31::1/8: prefix too short"))
+	gomega.Expect(vtv.report.Data[api.GlobalMsg][0]).To(gomega.Equal("Host tap addressing validation: 1 error found"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateDaemonSetGeneration(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDaemonSetGeneration("contiv-vswitch")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: vswitch pod on worker1 left at an older revision
+	var pod *telemetrymodel.Pod
+	for _, p := range vtv.k8sCache.RetrievePodsByHostIPAddr("10.20.0.10") {
+		if strings.HasPrefix(p.Name, "contiv-vswitch-") {
+			pod = p
+		}
+	}
+	gomega.Expect(pod).NotTo(gomega.BeNil())
+	for _, label := range pod.Label {
+		switch label.Key {
+		case "pod-template-generation":
+			label.Value = "2"
+		case "controller-revision-hash":
+			label.Value = "3391746902"
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDaemonSetGeneration("contiv-vswitch")
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		"has pod-template-generation 2, other pods have 1"))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][1]).To(gomega.ContainSubstring(
+		"has controller-revision-hash 3391746902, other pods have 1265075853"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateMinimumNodeCount(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMinimumNodeCount(3)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Fewer nodes than the configured minimum
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMinimumNodeCount(4)
+
+	checkDataReport(2, 0, 0)
+	gomega.Expect(vtv.report.FilterReport("too few nodes")).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: api.GlobalMsg, Message: "too few nodes in the cluster: got 3, expected at least 4"},
+	}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateConfiguredVni(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateConfiguredVni()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: VXLAN tunnel with a wrong VNI
+	k, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Vxlan.Vni = 20
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateConfiguredVni()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		fmt.Sprintf("vxlan_tunnel %s (ifIndex %d) has VNI 20, expected 10", ifp.If.Name, k)))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Overridden VNI
+	resetToInitialErrorFreeState()
+	vtv.l2Validator.VNI = 20
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateConfiguredVni()
+
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(1))
+	for _, node := range vtv.vppCache.RetrieveAllNodes() {
+		gomega.Expect(vtv.report.Data[node.Name]).To(gomega.HaveLen(2))
+	}
+
+	// Restore data back to error free state
+	vtv.l2Validator.VNI = 0
+	resetToInitialErrorFreeState()
+}
+
+func testValidateDnsPodReachability(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDnsPodReachability()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: DNS pod IP address from another node's pod network
+	pod, err := vtv.k8sCache.RetrievePod("kube-dns-86f4d74b45-tx7td", "kube-system")
+	gomega.Expect(err).To(gomega.BeNil())
+	pod.IPAddress = "10.1.3.10"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDnsPodReachability()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		"IP address 10.1.3.10 not in pod network 10.1.1.0/24"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: DNS pod tap interface missing
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_TAP_INTERFACE && ifc.IfMeta.Tag != "tap-vpp2" {
+			delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, k)
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateDnsPodReachability()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		"tap interface for IP address 10.1.1.2 not found"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateVrfAssignment(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	expected := map[interfaces.InterfaceType]uint32{
+		interfaces.InterfaceType_ETHERNET_CSMACD:   0,
+		interfaces.InterfaceType_VXLAN_TUNNEL:      0,
+		interfaces.InterfaceType_SOFTWARE_LOOPBACK: 1,
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVrfAssignment(expected)
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: VXLAN tunnel in the pod VRF
+	k, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Vrf = 1
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateVrfAssignment(expected)
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		fmt.Sprintf("interface %s (ifIndex %d, type VXLAN_TUNNEL) is in VRF 1, expected VRF 0", ifp.If.Name, k)))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTapVersionUniformity(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapVersionUniformity()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Tap interface left at an older version
+	var tapName string
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.Tag == "tap-vpp2" {
+			ifc.If.Tap.Version = 1
+			tapName = ifc.If.Name
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapVersionUniformity()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(tapName))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("has version 1, cluster majority is 2"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Node without tap interfaces
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_TAP_INTERFACE {
+			delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, k)
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapVersionUniformity()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testExpectedExtraInterfaces(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// ------------------------------------------------
+	// INJECT FAULT: Allowlisted monitoring loopback and a leftover loopback
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[100] = telemetrymodel.NodeInterface{
+		If: telemetrymodel.Interface{
+			Name:    "monitoringLoop",
+			IfType:  interfaces.InterfaceType_SOFTWARE_LOOPBACK,
+			Enabled: true,
+		},
+		IfMeta: telemetrymodel.InterfaceMeta{
+			SwIfIndex:       100,
+			VppInternalName: "loop1",
+		},
+	}
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[101] = telemetrymodel.NodeInterface{
+		If: telemetrymodel.Interface{
+			Name:    "leftoverLoop",
+			IfType:  interfaces.InterfaceType_SOFTWARE_LOOPBACK,
+			Enabled: true,
+		},
+		IfMeta: telemetrymodel.InterfaceMeta{
+			SwIfIndex:       101,
+			VppInternalName: "loop2",
+		},
+	}
+	vtv.l2Validator.ExpectedExtraInterfaces = []string{"monitoring.*"}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("[leftoverLoop vxlanBVI]"))
+
+	// ------------------------------------------------
+	// Allowlist both extra loopbacks, one by exact name
+	vtv.l2Validator.ExpectedExtraInterfaces = []string{"monitoring.*", "leftoverLoop"}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// A regular expression must match the whole interface name
+	vtv.l2Validator.ExpectedExtraInterfaces = []string{"monitoring"}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateLoopbackCount()
+
+	checkDataReport(1, 1, 0)
+
+	// Restore data back to error free state
+	vtv.l2Validator.ExpectedExtraInterfaces = nil
+	resetToInitialErrorFreeState()
+}
+
+func testValidateBdHasBvi(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdHasBvi()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: BVI flag removed from the bridge domain
+	bdIdx, err := getVxlanBD(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	bd := vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	for i := range bd.Bd.Interfaces {
+		bd.Bd.Interfaces[i].BVI = false
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdHasBvi()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("vxlanBD has 0 BVI interfaces"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Two interfaces designated as BVI in the bridge domain
+	resetToInitialErrorFreeState()
+	bd = vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	for i := range bd.Bd.Interfaces {
+		bd.Bd.Interfaces[i].BVI = true
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdHasBvi()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		fmt.Sprintf("vxlanBD has %d BVI interfaces", len(bd.Bd.Interfaces))))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidatePodGatewayCollision(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodGatewayCollision()
+
+	checkDataReport(1, 0, 0)
+
+	// -----------------------------------------------
+	// INJECT FAULT: Pod assigned the gateway address of its node
+	err := vtv.k8sCache.CreatePod("bogus-pod", "default", nil, "10.0.1.1", "10.20.0.10", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodGatewayCollision()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(
+		"pod bogus-pod (namespace default) IP address 10.0.1.1 collides with the node's gateway address 10.0.1.1"))
+
+	// -----------------------------------------------
+	// INJECT FAULT: Pod assigned the gateway address of another node
+	resetToInitialErrorFreeState()
+	err = vtv.k8sCache.CreatePod("bogus-pod", "default", nil, "10.0.0.1", "10.20.0.10", nil)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodGatewayCollision()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateManagementSubnet(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("10.20.0.0/24")
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Node on the wrong management network
+	vtv.vppCache.NodeMap[vtv.nodeKey].ManIPAddr = "10.30.0.11"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("10.20.0.0/24")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"management IP address 10.30.0.11 is not in the management subnet 10.20.0.0/24"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Management IP address not set
+	vtv.vppCache.NodeMap[vtv.nodeKey].ManIPAddr = ""
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("10.20.0.0/24")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal("management IP not set"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Invalid subnet
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("10.20.0.0/33")
+
+	checkDataReport(2, 0, 0)
+	gomega.Expect(vtv.report.Data[api.GlobalMsg][0]).To(gomega.ContainSubstring("invalid management subnet"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateL2FibOutgoingType(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibOutgoingType()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: L2Fib entry pointing at the GigE interface
+	var gigeIdx uint32
+	for _, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			gigeIdx = ifc.IfMeta.SwIfIndex
+		}
+	}
+	var mac string
+	for k, fe := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs {
+		if !fe.Fe.BridgedVirtualInterface {
+			fe.FeMeta.OutgoingIfIndex = gigeIdx
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs[k] = fe
+			mac = fe.Fe.PhysAddress
+			break
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibOutgoingType()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring(mac))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("of type ETHERNET_CSMACD"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: L2Fib entry pointing at a non-existent interface
+	resetToInitialErrorFreeState()
+	for k, fe := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs {
+		if fe.Fe.BridgedVirtualInterface {
+			fe.FeMeta.OutgoingIfIndex = 1000
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs[k] = fe
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateL2FibOutgoingType()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("ifIndex 1000 not found"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateUplinkPresence(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUplinkPresence()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Uplink interface down
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.Enabled = false
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUplinkPresence()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.HavePrefix("uplink down/no IP"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Uplink interface without an IP address
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.IPAddresses = nil
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUplinkPresence()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.HavePrefix("uplink down/no IP"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: No uplink interface
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, k)
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUplinkPresence()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.HavePrefix("no uplink"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateBdInterfaceNameIndexMatch(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdInterfaceNameIndexMatch()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Names of two interfaces swapped in the BD index mapping
+	bdIdx, err := getVxlanBD(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	bd := vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	ifIndices := make([]uint32, 0)
+	for ifIdx := range bd.BdMeta.BdID2Name {
+		ifIndices = append(ifIndices, ifIdx)
+	}
+	gomega.Expect(len(ifIndices)).To(gomega.BeNumerically(">=", 2))
+	bd.BdMeta.BdID2Name[ifIndices[0]], bd.BdMeta.BdID2Name[ifIndices[1]] =
+		bd.BdMeta.BdID2Name[ifIndices[1]], bd.BdMeta.BdID2Name[ifIndices[0]]
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdInterfaceNameIndexMatch()
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.ContainSubstring("bridge domain vxlanBD: interface"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: BD interface not in the interface map
+	resetToInitialErrorFreeState()
+	bd = vtv.vppCache.NodeMap[vtv.nodeKey].NodeBridgeDomains[bdIdx]
+	bd.Bd.Interfaces[0].Name = "bogusInterface"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateBdInterfaceNameIndexMatch()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"bridge domain vxlanBD: interface bogusInterface not found in interface map"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTunnelCardinality(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelCardinality()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Leftover VXLAN tunnel
+	_, ifp := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	gomega.Expect(ifp).NotTo(gomega.BeNil())
+	ifp.If.Name = "vxlan_leftover"
+	ifp.IfMeta.SwIfIndex = 99
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[99] = *ifp
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelCardinality()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"too many VXLAN tunnels (leftover): expected 2, got 3"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Missing VXLAN tunnel
+	resetToInitialErrorFreeState()
+	ifIdx, _ := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, ifIdx)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelCardinality()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"too few VXLAN tunnels (missing peer): expected 2, got 1"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateAllowedNamespaces(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAllowedNamespaces([]string{"kube-system", "default"})
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Pod in an unexpected namespace
+	pod, err := vtv.k8sCache.RetrievePod("nginx-768979984b-7lgkl", "default")
+	gomega.Expect(err).To(gomega.BeNil())
+	pod.Namespace = "tenant1"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAllowedNamespaces([]string{"kube-system", "default"})
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal("pod nginx-768979984b-7lgkl is in " +
+		"namespace tenant1, which is not in the allowed namespaces [default kube-system]"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Namespace not in the allowlist
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateAllowedNamespaces([]string{"kube-system"})
+
+	gomega.Expect(vtv.report.FilterReport("is in namespace default")).To(gomega.HaveLen(3))
+	gomega.Expect(vtv.report.FilterReport("is in namespace kube-system")).To(gomega.BeEmpty())
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateMacAddressFormat(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMacAddressFormat()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------------
+	// INJECT FAULT: Malformed MAC address on a node interface
+	loopIf, err := datastore.GetNodeLoopIFInfo(vtv.vppCache.NodeMap[vtv.nodeKey])
+	gomega.Expect(err).To(gomega.BeNil())
+	loopIf.If.PhysAddress = "12:34:56:78"
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[int(loopIf.IfMeta.SwIfIndex)] = *loopIf
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMacAddressFormat()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(fmt.Sprintf(
+		"malformed MAC address '12:34:56:78' in phys_address of interface %s (ifIndex %d)",
+		loopIf.If.Name, loopIf.IfMeta.SwIfIndex)))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+
+	// --------------------------------------------------
+	// INJECT FAULT: Malformed MAC addresses in an ARP and an L2Fib entry
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeIPArp[0].Ae.PhysAddress = "1a:2b:3c:4d:5e:zz"
+	for k, fibEntry := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs {
+		fibEntry.Fe.PhysAddress = ""
+		vtv.vppCache.NodeMap[vtv.nodeKey].NodeL2Fibs[k] = fibEntry
+		break
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMacAddressFormat()
+
+	checkDataReport(1, 2, 0)
+	gomega.Expect(vtv.report.FilterReport("'1a:2b:3c:4d:5e:zz' in phys_address of ARP entry")).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.FilterReport("'' in phys_address of L2Fib entry")).To(gomega.HaveLen(1))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidatePodCidrDisjointness(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodCidrDisjointness()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Overlapping pod CIDRs on two nodes
+	k8sNode, err := vtv.k8sCache.RetrieveK8sNode("k8s-worker2")
+	gomega.Expect(err).To(gomega.BeNil())
+	k8sNode.Pod_CIDR = "10.0.1.128/25"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodCidrDisjointness()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"pod CIDR 10.0.1.0/24 overlaps pod CIDR 10.0.1.128/25 of node k8s-worker2 in range 10.0.1.128/25"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateInterfaceVsLivenessState(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceVsLivenessState()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Agent not running, but interfaces enabled
+	node := vtv.vppCache.NodeMap[vtv.nodeKey]
+	node.NodeLiveness.State = 2
+	enabledCnt := 0
+	for _, intf := range node.NodeInterfaces {
+		if intf.If.Enabled {
+			enabledCnt++
+		}
+	}
+	gomega.Expect(enabledCnt).To(gomega.BeNumerically(">", 0))
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceVsLivenessState()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(fmt.Sprintf("suspect interface data: "+
+		"liveness state is 2 (not running), but %d interfaces are enabled", enabledCnt)))
+
+	// ------------------------------------------------
+	// Agent not running and all interfaces disabled is consistent
+	for k, intf := range node.NodeInterfaces {
+		intf.If.Enabled = false
+		node.NodeInterfaces[k] = intf
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateInterfaceVsLivenessState()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateGlobalInvariants(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGlobalInvariants()
+
+	checkDataReport(numGlobalInvariantMessages, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Missing VXLAN tunnel
+	ifIdx, _ := vtv.findFirstVxlanInterface(vtv.nodeKey)
+	delete(vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces, ifIdx)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGlobalInvariants()
+
+	checkDataReport(numGlobalInvariantMessages, 0, 0)
+	gomega.Expect(vtv.report.GlobalMessages()).To(gomega.Equal([]api.ReportEntry{
+		{NodeName: api.GlobalMsg, Message: "cluster size: 3 VPP nodes, 3 K8s nodes"},
+		{NodeName: api.GlobalMsg, Message: "VXLAN mesh: 5 of 6 tunnels present"},
+		{NodeName: api.GlobalMsg, Message: "Global invariants validation: OK"},
+	}))
+
+	// The missing tunnel is reported once, by the tunnel cardinality check
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelCardinality()
+
+	checkDataReport(1, 1, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testIPv6Addresses(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// ------------------------------------------------
+	// Management subnet membership with IPv6 management addresses
+	for i, nodeName := range []string{"k8s-master", "k8s-worker1", "k8s-worker2"} {
+		vtv.vppCache.NodeMap[nodeName].ManIPAddr = fmt.Sprintf("fd00:20::%d", i+2)
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("fd00:20::/64")
+
+	checkDataReport(1, 0, 0)
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("fd00:30::/64")
+
+	checkDataReport(1, 1, 1)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"management IP address fd00:20::4 is not in the management subnet fd00:30::/64"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: IPv4 management address in an IPv6 management subnet
+	vtv.vppCache.NodeMap[vtv.nodeKey].ManIPAddr = "10.20.0.11"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateManagementSubnet("fd00:20::/64")
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal("management IP address family mismatch: " +
+		"IPv4 address 10.20.0.11, IPv6 subnet fd00:20::/64"))
+
+	// ------------------------------------------------
+	// GigE IP address uniqueness with IPv6 addresses
+	resetToInitialErrorFreeState()
+	setGigEIPAddresses := func(nodeName string, ipAddresses ...string) {
+		for k, ifc := range vtv.vppCache.NodeMap[nodeName].NodeInterfaces {
+			if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+				ifc.If.IPAddresses = ipAddresses
+				vtv.vppCache.NodeMap[nodeName].NodeInterfaces[k] = ifc
+			}
+		}
+	}
+	setGigEIPAddresses("k8s-worker1", "192.168.16.2/24", "fd00:16::2/64")
+	setGigEIPAddresses("k8s-worker2", "192.168.16.3/24", "fd00:16::3/64")
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGigEIpUniqueness()
+
+	checkDataReport(1, 0, 0)
+
+	// INJECT FAULT: Two nodes with the same IPv6 GigE address (in a
+	// different notation)
+	setGigEIPAddresses("k8s-worker2", "192.168.16.3/24", "fd00:16:0::0:2/64")
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateGigEIpUniqueness()
+
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data["k8s-worker1"]).To(gomega.Equal([]string{
+		"duplicate GigE IP address fd00:16::2, shared by nodes k8s-worker1, k8s-worker2"}))
+	gomega.Expect(vtv.report.Data["k8s-worker2"]).To(gomega.HaveLen(1))
+
+	// ------------------------------------------------
+	// Pod CIDR membership with an IPv6 pod network
+	resetToInitialErrorFreeState()
+	k8sNode, err := vtv.k8sCache.RetrieveK8sNode(vtv.nodeKey)
+	gomega.Expect(err).To(gomega.BeNil())
+	k8sNode.Pod_CIDR = "fd00:1:3::/120"
+	node := vtv.vppCache.NodeMap[vtv.nodeKey]
+	node.NodeIPam.Config.PodIfIPCIDR = "fd00:2:1::/120"
+	for k, ifc := range node.NodeInterfaces {
+		for i, ipAddr := range ifc.If.IPAddresses {
+			if strings.HasPrefix(ipAddr, "10.2.1.") {
+				ifc.If.IPAddresses[i] = "fd00:2:1::" + strings.TrimSuffix(strings.TrimPrefix(ipAddr, "10.2.1."), "/32") +
+					"/128"
+			}
+		}
+		node.NodeInterfaces[k] = ifc
+	}
+	for _, pod := range vtv.k8sCache.RetrievePodsByHostIPAddr(node.ManIPAddr) {
+		if strings.HasPrefix(pod.IPAddress, "10.1.3.") {
+			pod.IPAddress = "fd00:1:3::" + strings.TrimPrefix(pod.IPAddress, "10.1.3.")
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodInfo()
+
+	checkDataReport(1, 0, 0)
+	pod, err := vtv.k8sCache.RetrievePod("nginx-768979984b-8ksk6", "default")
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(pod.VppIfIPAddr).To(gomega.Equal("fd00:2:1::3/128"))
+
+	// INJECT FAULT: IPv4 pod IP address in an IPv6 Pod CIDR
+	pod.IPAddress = "10.1.3.3"
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidatePodInfo()
+
+	gomega.Expect(vtv.report.FilterReport("family mismatch")).To(gomega.Equal([]api.ReportEntry{{
+		NodeName: vtv.nodeKey,
+		Message: "pod nginx-768979984b-8ksk6: IP address family mismatch: IPv4 address 10.1.3.3, " +
+			"IPv6 Pod CIDR fd00:1:3::/120",
+	}}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTunnelsEnabled(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelsEnabled()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Disabled VXLAN tunnel
+	ifIdx := vtv.findVxlanInterfaceTo(vtv.nodeKey, "k8s-worker2")
+	ifc := vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx]
+	ifc.If.Enabled = false
+	vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[ifIdx] = ifc
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTunnelsEnabled()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(fmt.Sprintf(
+		"VXLAN tunnel %s (ifIndex %d) to %s (node k8s-worker2) is disabled",
+		ifc.If.Name, ifc.IfMeta.SwIfIndex, ifc.If.Vxlan.DstAddress)))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateUnderlaySubnetUniformity(t *testing.T) {
+	vtv.nodeKey = "k8s-worker1"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUnderlaySubnetUniformity()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Node plugged into a different underlay subnet
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.IPAddresses = []string{"192.168.17.2/24"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUnderlaySubnetUniformity()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"GigE subnet 192.168.17.0/24 differs from the underlay subnet 192.168.16.0/24 of the cluster majority"))
+
+	// ------------------------------------------------
+	// INJECT FAULT: Node without a GigE IP address
+	resetToInitialErrorFreeState()
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.If.IfType == interfaces.InterfaceType_ETHERNET_CSMACD {
+			ifc.If.IPAddresses = nil
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateUnderlaySubnetUniformity()
+
+	checkDataReport(1, 0, 0)
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateTapAddressInPodCidr(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Pod taps in the sample data are addressed from the IPAM pod subnet
+	// (10.2.1.0/24), which is not within the K8s Pod CIDRs (10.0.x.0/24)
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapAddressInPodCidr()
+
+	gomega.Expect(vtv.report.Data[vtv.nodeKey]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data["k8s-worker1"]).To(gomega.HaveLen(1))
+	gomega.Expect(vtv.report.Data["k8s-worker2"]).To(gomega.HaveLen(2))
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"pod tap tapb68170b0423ec69 (ifIndex 4) IP address 10.2.1.2/32 is outside the node's Pod CIDR 10.0.0.0/24"))
+	gomega.Expect(vtv.report.Data[api.GlobalMsg][0]).To(gomega.Equal(
+		"Tap address Pod CIDR validation: 4 errors found"))
+
+	// ------------------------------------------------
+	// Align the K8s Pod CIDRs with the IPAM pod subnet
+	for _, k8sNode := range vtv.k8sCache.RetrieveAllK8sNodes() {
+		k8sNode.Pod_CIDR = "10.2.1.0/24"
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapAddressInPodCidr()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Pod tap addressed outside the node's Pod CIDR
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if ifc.IfMeta.Tag == "tapb68170b0423ec69" {
+			ifc.If.IPAddresses = []string{"10.2.2.7/32"}
+			vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateTapAddressInPodCidr()
+
+	checkDataReport(1, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"pod tap tapb68170b0423ec69 (ifIndex 4) IP address 10.2.2.7/32 is outside the node's Pod CIDR 10.2.1.0/24"))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateMtuBounds(t *testing.T) {
+	vtv.nodeKey = "k8s-master"
+	resetToInitialErrorFreeState()
+
+	// Interfaces without an MTU in the sample data are reported as unset
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMtuBounds()
+
+	gomega.Expect(vtv.report.FilterReport("MTU is unset")).To(gomega.HaveLen(12))
+	gomega.Expect(vtv.report.FilterReport("out of bounds")).To(gomega.BeEmpty())
+
+	// ------------------------------------------------
+	// Set the MTU of all interfaces
+	for _, node := range vtv.vppCache.RetrieveAllNodes() {
+		for k, ifc := range node.NodeInterfaces {
+			if ifc.If.Mtu == 0 {
+				ifc.If.Mtu = 1500
+				node.NodeInterfaces[k] = ifc
+			}
+		}
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMtuBounds()
+
+	checkDataReport(1, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: MTUs below the IP minimum, above the hardware maximum
+	// and unset
+	mtus := []uint32{67, 9217, 0}
+	ifcs := make([]telemetrymodel.NodeInterface, 0)
+	for k, ifc := range vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces {
+		if len(ifcs) == len(mtus) {
+			break
+		}
+		ifc.If.Mtu = mtus[len(ifcs)]
+		vtv.vppCache.NodeMap[vtv.nodeKey].NodeInterfaces[k] = ifc
+		ifcs = append(ifcs, ifc)
+	}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateMtuBounds()
+
+	checkDataReport(1, 3, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey]).To(gomega.ConsistOf(
+		fmt.Sprintf("interface %s (ifIndex %d) MTU 67 is out of bounds [68, 9216]",
+			ifcs[0].If.Name, ifcs[0].IfMeta.SwIfIndex),
+		fmt.Sprintf("interface %s (ifIndex %d) MTU 9217 is out of bounds [68, 9216]",
+			ifcs[1].If.Name, ifcs[1].IfMeta.SwIfIndex),
+		fmt.Sprintf("interface %s (ifIndex %d) MTU is unset",
+			ifcs[2].If.Name, ifcs[2].IfMeta.SwIfIndex)))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+func testValidateCollectedVsKnownNodes(t *testing.T) {
+	vtv.nodeKey = "k8s-worker2"
+	resetToInitialErrorFreeState()
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateCollectedVsKnownNodes()
+
+	checkDataReport(2, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: K8s node never scraped
+	node := vtv.vppCache.NodeMap[vtv.nodeKey]
+	node.NodeLiveness = nil
+	node.NodeInterfaces = nil
+	node.NodeBridgeDomains = nil
+	node.NodeL2Fibs = nil
+	node.NodeIPArp = nil
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateCollectedVsKnownNodes()
+
+	checkDataReport(2, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal(
+		"K8s node k8s-worker2 was not collected: missing liveness, interfaces, BDs, L2FIBs, ARPs"))
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.Equal([]string{
+		"K8s node collection validation: 1 error found",
+		"Collected node K8s membership validation: OK",
+	}))
+
+	// ------------------------------------------------
+	// Received but empty L2FIB and ARP tables do not make a node uncollected
+	resetToInitialErrorFreeState()
+	node = vtv.vppCache.NodeMap[vtv.nodeKey]
+	node.NodeL2Fibs = telemetrymodel.NodeL2FibTable{}
+	node.NodeIPArp = telemetrymodel.NodeIPArpTable{}
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateCollectedVsKnownNodes()
+
+	checkDataReport(2, 0, 0)
+
+	// ------------------------------------------------
+	// K8s node missing in the VPP node cache is left to ValidateK8sNodeInfo
+	resetToInitialErrorFreeState()
+	err := vtv.vppCache.DeleteNode(vtv.nodeKey)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateCollectedVsKnownNodes()
+
+	checkDataReport(2, 0, 0)
+
+	// ------------------------------------------------
+	// INJECT FAULT: Collected node unknown to K8s
+	resetToInitialErrorFreeState()
+	err = vtv.k8sCache.DeleteK8sNode(vtv.nodeKey)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// Perform test
+	vtv.report.Clear()
+	vtv.l2Validator.ValidateCollectedVsKnownNodes()
+
+	checkDataReport(2, 1, 0)
+	gomega.Expect(vtv.report.Data[vtv.nodeKey][0]).To(gomega.Equal("collected node k8s-worker2 is not known to K8s"))
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.Equal([]string{
+		"K8s node collection validation: OK",
+		"Collected node K8s membership validation: 1 error found",
+	}))
+
+	// Restore data back to error free state
+	resetToInitialErrorFreeState()
+}
+
+// testConcurrentValidation validates many synthetic nodes with multiple
+// workers; run with -race to detect unsynchronized access to the report.
+func testConcurrentValidation(t *testing.T) {
+	const numNodes = 100
+	resetToInitialErrorFreeState()
+
+	// Clone the sample nodes; every third clone has all VXLAN tunnels
+	// disabled
+	sampleNodes := vtv.vppCache.RetrieveAllNodes()
+	numDisabled := 0
+	for i := 0; i < numNodes; i++ {
+		sample := sampleNodes[i%len(sampleNodes)]
+		name := fmt.Sprintf("synthetic-%03d", i)
+		err := vtv.vppCache.CreateNode(uint32(100+i), name, fmt.Sprintf("192.168.17.%d", i),
+			fmt.Sprintf("10.20.1.%d", i))
+		gomega.Expect(err).To(gomega.BeNil())
+
+		node := vtv.vppCache.NodeMap[name]
+		node.NodeLiveness = sample.NodeLiveness
+		node.NodeBridgeDomains = sample.NodeBridgeDomains
+		node.NodeL2Fibs = sample.NodeL2Fibs
+		node.NodeIPArp = sample.NodeIPArp
+		node.NodeIPam = sample.NodeIPam
+		node.NodeInterfaces = make(telemetrymodel.NodeInterfaces)
+		for k, ifc := range sample.NodeInterfaces {
+			if i%3 == 0 && ifc.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL {
+				ifc.If.Enabled = false
+				numDisabled++
+			}
+			node.NodeInterfaces[k] = ifc
+		}
+	}
+
+	// Perform test: sequential
+	vtv.l2Validator.Workers = 1
+	vtv.report.Clear()
+	vtv.l2Validator.Validate()
+	sequential := vtv.report.Data.DeepCopy()
+
+	gomega.Expect(sequential[api.GlobalMsg]).To(gomega.ContainElement(
+		fmt.Sprintf("VXLAN tunnel state validation: %d errors found", numDisabled)))
+
+	// Perform test: concurrent
+	vtv.l2Validator.Workers = 8
+	vtv.report.Clear()
+	vtv.l2Validator.Validate()
+
+	// The summaries are recorded in the same order; entries recorded for
+	// a node may be in a different order, since nodes' data is kept in maps
+	gomega.Expect(vtv.report.Data[api.GlobalMsg]).To(gomega.Equal(sequential[api.GlobalMsg]))
+	gomega.Expect(vtv.report.Data).To(gomega.HaveLen(len(sequential)))
+	for nodeName, entries := range sequential {
+		gomega.Expect(sortedCopy(vtv.report.Data[nodeName])).To(gomega.Equal(sortedCopy(entries)))
+	}
+
+	// Restore data back to error free state
+	vtv.l2Validator.Workers = 0
+	resetToInitialErrorFreeState()
+}
+
+func (v *l2ValidatorTestVars) findVxlanInterfaceTo(nodeKey string, dstNodeKey string) int {
+	for k, ifc := range v.vppCache.NodeMap[nodeKey].NodeInterfaces {
+		if ifc.If.IfType != interfaces.InterfaceType_VXLAN_TUNNEL {
+			continue
+		}
+		if n, err := v.vppCache.RetrieveNodeByGigEIPAddr(ifc.If.Vxlan.DstAddress); err == nil && n.Name == dstNodeKey {
+			return k
+		}
+	}
+	return -1
+}
+
 func (v *l2ValidatorTestVars) findFirstVxlanInterface(nodeKey string) (int, *telemetrymodel.NodeInterface) {
 	for k, ifc := range v.vppCache.NodeMap[nodeKey].NodeInterfaces {
 		if ifc.If.IfType == interfaces.InterfaceType_VXLAN_TUNNEL {
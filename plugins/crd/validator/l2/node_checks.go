@@ -0,0 +1,243 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package l2
+
+import (
+	"github.com/contiv/vpp/plugins/crd/api"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"sync"
+)
+
+// nodeCheck is a validation that checks each node independently of the
+// other nodes, so that nodes can be validated concurrently. check
+// validates the nodes in nodeList, records the errors found into report
+// and returns the number of errors found; kind names the validation in
+// its summary.
+type nodeCheck struct {
+	kind  string
+	check func(v *Validator, nodeList []*telemetrymodel.Node, report *reportBuffer) int
+}
+
+var (
+	disabledInterfaceStateCheck    = nodeCheck{"Interface state", (*Validator).checkDisabledInterfaceState}
+	vxlanMtuHeadroomCheck          = nodeCheck{"VXLAN MTU headroom", (*Validator).checkVxlanMtuHeadroom}
+	loopbackCountCheck             = nodeCheck{"Loopback count", (*Validator).checkLoopbackCount}
+	l2FibStaticnessCheck           = nodeCheck{"L2Fib staticness", (*Validator).checkL2FibStaticness}
+	splitHorizonGroupsCheck        = nodeCheck{"Split-horizon group", (*Validator).checkSplitHorizonGroups}
+	interfaceIndexConsistencyCheck = nodeCheck{"Interface index consistency",
+		(*Validator).checkInterfaceIndexConsistency}
+	tapToPodParityCheck            = nodeCheck{"Tap to pod parity", (*Validator).checkTapToPodParity}
+	configuredVniCheck             = nodeCheck{"VXLAN VNI", (*Validator).checkConfiguredVni}
+	bdHasBviCheck                  = nodeCheck{"BD BVI", (*Validator).checkBdHasBvi}
+	l2FibOutgoingTypeCheck         = nodeCheck{"L2Fib outgoing interface", (*Validator).checkL2FibOutgoingType}
+	uplinkPresenceCheck            = nodeCheck{"Uplink", (*Validator).checkUplinkPresence}
+	bdInterfaceNameIndexMatchCheck = nodeCheck{"BD interface name/index",
+		(*Validator).checkBdInterfaceNameIndexMatch}
+	macAddressFormatCheck          = nodeCheck{"MAC address format", (*Validator).checkMacAddressFormat}
+	interfaceVsLivenessStateCheck  = nodeCheck{"Interface liveness state", (*Validator).checkInterfaceVsLivenessState}
+	tunnelsEnabledCheck            = nodeCheck{"VXLAN tunnel state", (*Validator).checkTunnelsEnabled}
+	podInfoCheck                   = nodeCheck{"K8sPod", (*Validator).checkPodInfo}
+	ipAddressFormatCheck           = nodeCheck{"IP address format", (*Validator).checkIPAddressFormat}
+	vxlanUnderlayReachabilityCheck = nodeCheck{"VXLAN underlay reachability",
+		(*Validator).checkVxlanUnderlayReachability}
+	managementIpMatchCheck    = nodeCheck{"Management IP", (*Validator).checkManagementIpMatch}
+	orphanedVxlanTunnelsCheck = nodeCheck{"Orphaned VXLAN tunnel", (*Validator).checkOrphanedVxlanTunnels}
+	bviIpEncodesNodeIdCheck   = nodeCheck{"BVI node ID", (*Validator).checkBviIpEncodesNodeId}
+)
+
+// bridgeDomainCountCheck returns the check of the number of bridge domains
+// on each node against the expected number.
+func bridgeDomainCountCheck(expected int) nodeCheck {
+	return nodeCheck{"BD count", func(v *Validator, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+		return v.checkBridgeDomainCount(expected, nodeList, report)
+	}}
+}
+
+// arpTablesCheck returns the check of the ARP tables of each node against
+// the nodes in clusterNodes.
+func arpTablesCheck(clusterNodes []*telemetrymodel.Node) nodeCheck {
+	return nodeCheck{"IP ARP", func(v *Validator, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+		return v.checkArpTables(clusterNodes, nodeList, report)
+	}}
+}
+
+// bridgeDomainsCheck returns the check of the Vxlan BD of each node against
+// the nodes in clusterNodes.
+func bridgeDomainsCheck(clusterNodes []*telemetrymodel.Node) nodeCheck {
+	return nodeCheck{"BD", func(v *Validator, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+		return v.checkBridgeDomains(clusterNodes, nodeList, report)
+	}}
+}
+
+// l2FibEntriesCheck returns the check of the L2Fib entries of each node
+// against the nodes in clusterNodes.
+func l2FibEntriesCheck(clusterNodes []*telemetrymodel.Node) nodeCheck {
+	return nodeCheck{"L2Fib", func(v *Validator, nodeList []*telemetrymodel.Node, report *reportBuffer) int {
+		return v.checkL2FibEntries(clusterNodes, nodeList, report)
+	}}
+}
+
+// hostTapAddressingCheck returns the check of the host tap addresses of
+// each node, including their uniqueness among the nodes in clusterNodes.
+func hostTapAddressingCheck(clusterNodes []*telemetrymodel.Node) nodeCheck {
+	ipNodes := hostTapIPNodes(clusterNodes)
+	return nodeCheck{"Host tap addressing", func(v *Validator, nodeList []*telemetrymodel.Node,
+		report *reportBuffer) int {
+		return v.checkHostTapAddressing(ipNodes, nodeList, report)
+	}}
+}
+
+// tunnelCardinalityCheck returns the check of the number of VXLAN tunnels
+// on each node against the expected number.
+func tunnelCardinalityCheck(expected int) nodeCheck {
+	return nodeCheck{"VXLAN tunnel count", func(v *Validator, nodeList []*telemetrymodel.Node,
+		report *reportBuffer) int {
+		return v.checkTunnelCardinality(expected, nodeList, report)
+	}}
+}
+
+// nodeChecks returns the per-node checks performed by Validate() for the
+// nodes in clusterNodes, in the order in which their summaries are
+// recorded.
+func (v *Validator) nodeChecks(clusterNodes []*telemetrymodel.Node) []nodeCheck {
+	checks := []nodeCheck{
+		arpTablesCheck(clusterNodes),
+		bridgeDomainsCheck(clusterNodes),
+		l2FibEntriesCheck(clusterNodes),
+		podInfoCheck,
+		ipAddressFormatCheck,
+		vxlanUnderlayReachabilityCheck,
+		managementIpMatchCheck,
+		orphanedVxlanTunnelsCheck,
+		hostTapAddressingCheck(clusterNodes),
+		tunnelCardinalityCheck(len(clusterNodes) - 1),
+		disabledInterfaceStateCheck,
+		bridgeDomainCountCheck(numBridgeDomains),
+		vxlanMtuHeadroomCheck,
+		loopbackCountCheck,
+		l2FibStaticnessCheck,
+		splitHorizonGroupsCheck,
+		interfaceIndexConsistencyCheck,
+		tapToPodParityCheck,
+		configuredVniCheck,
+		bdHasBviCheck,
+		l2FibOutgoingTypeCheck,
+		uplinkPresenceCheck,
+		bdInterfaceNameIndexMatchCheck,
+		macAddressFormatCheck,
+		interfaceVsLivenessStateCheck,
+		tunnelsEnabledCheck,
+	}
+	if v.BviIPEncodesNodeID {
+		checks = append(checks, bviIpEncodesNodeIdCheck)
+	}
+	return checks
+}
+
+// runNodeChecks performs the checks for all nodes in the cache, with up to
+// Workers nodes validated concurrently. The entries recorded for each node
+// are buffered and added to the report in node order once all nodes are
+// validated, followed by the summary of each check, so that the report
+// does not depend on the number of workers.
+func (v *Validator) runNodeChecks(checks ...nodeCheck) {
+	nodeList := v.VppCache.RetrieveAllNodes()
+
+	workers := v.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	buffers := make([]reportBuffer, len(nodeList))
+	errCnts := make([][]int, len(nodeList))
+	nodeIdxs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for nodeIdx := range nodeIdxs {
+				errCnts[nodeIdx] = make([]int, len(checks))
+				for checkIdx, check := range checks {
+					errCnts[nodeIdx][checkIdx] = check.check(v, nodeList[nodeIdx:nodeIdx+1], &buffers[nodeIdx])
+				}
+			}
+		}()
+	}
+	for nodeIdx := range nodeList {
+		nodeIdxs <- nodeIdx
+	}
+	close(nodeIdxs)
+	wg.Wait()
+
+	for nodeIdx := range nodeList {
+		buffers[nodeIdx].flush(v.Report)
+	}
+	for checkIdx, check := range checks {
+		errCnt := 0
+		for nodeIdx := range nodeList {
+			errCnt += errCnts[nodeIdx][checkIdx]
+		}
+		v.addSummary(errCnt, check.kind)
+	}
+}
+
+// reportBuffer holds the report entries recorded by the checks of a single
+// node until they are added to the report.
+type reportBuffer struct {
+	entries []bufferedEntry
+}
+
+// bufferedEntry is a report entry held in a reportBuffer. logErr marks
+// entries to be logged as errors when added to the report.
+type bufferedEntry struct {
+	nodeName string
+	severity api.Severity
+	category string
+	msg      string
+	logErr   bool
+}
+
+// AppendToNodeReportWithCategory buffers the error string tagged with the
+// category for the node.
+func (b *reportBuffer) AppendToNodeReportWithCategory(nodeName string, category string, errString string) {
+	b.Append(nodeName, api.SeverityError, category, errString)
+}
+
+// LogErrAndAppendToNodeReportWithCategory buffers the error string tagged
+// with the category for the node; the error is logged when the buffer is
+// flushed.
+func (b *reportBuffer) LogErrAndAppendToNodeReportWithCategory(nodeName string, category string,
+	errString string) {
+	b.entries = append(b.entries, bufferedEntry{nodeName, api.SeverityError, category, errString, true})
+}
+
+// Append buffers the string with its severity and category for the node.
+func (b *reportBuffer) Append(nodeName string, severity api.Severity, category string, msg string) {
+	b.entries = append(b.entries, bufferedEntry{nodeName, severity, category, msg, false})
+}
+
+// flush adds the buffered entries to the report in the order in which they
+// were recorded and empties the buffer.
+func (b *reportBuffer) flush(report api.Report) {
+	for _, entry := range b.entries {
+		if entry.logErr {
+			report.LogErrAndAppendToNodeReportWithCategory(entry.nodeName, entry.category, entry.msg)
+			continue
+		}
+		report.Append(entry.nodeName, entry.severity, entry.category, entry.msg)
+	}
+	b.entries = nil
+}
@@ -33,6 +33,9 @@ type Validator struct {
 	VppCache api.VppCache
 	K8sCache api.K8sCache
 	Report   api.Report
+
+	// results holds the results of the validations performed so far
+	results []api.ValidationResult
 }
 
 //Vrf is a type declaration to help simplify a map of maps
@@ -48,7 +51,7 @@ func (v *Validator) Validate() {
 
 		vrfMap, err := v.createVrfMap(node)
 		if err != nil {
-			v.Report.LogErrAndAppendToNodeReport(node.Name, err.Error())
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, err.Error())
 		}
 
 		// Validate routes to local pods (they are all on vrf 1).
@@ -74,18 +77,27 @@ func (v *Validator) Validate() {
 	for routeIP, bl := range routeMap {
 		if !bl {
 			errString := fmt.Sprintf("Error validating L3 connectivity for route %s:", routeIP)
-			v.Report.AppendToNodeReport(api.GlobalMsg, errString)
+			v.Report.AppendToNodeReportWithCategory(api.GlobalMsg, api.CategoryRoutes, errString)
 		}
 	}
 
+	v.results = append(v.results, api.ValidationResult{Name: "L3", ErrorCount: numErrs})
 	if numErrs == 0 {
-		v.Report.AppendToNodeReport(api.GlobalMsg, "success validating l3 info.")
+		v.Report.Append(api.GlobalMsg, api.SeverityInfo, api.CategorySummary, "success validating l3 info.")
 	} else {
 		errString := fmt.Sprintf("%d Errors in L3 validation...", numErrs)
-		v.Report.AppendToNodeReport(api.GlobalMsg, errString)
+		v.Report.Append(api.GlobalMsg, api.SeverityError, api.CategorySummary, errString)
 	}
 }
 
+// Results returns the results of the validations performed by the
+// Validator, in the order in which they were performed.
+func (v *Validator) Results() []api.ValidationResult {
+	results := make([]api.ValidationResult, len(v.results))
+	copy(results, v.results)
+	return results
+}
+
 func (v *Validator) createVrfMap(node *telemetrymodel.Node) (map[uint32]Vrf, error) {
 	vrfMap := make(map[uint32]Vrf, 0)
 	for _, route := range node.NodeStaticRoutes {
@@ -121,7 +133,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("missing route for Pod '%s' with IP Address %s",
 				pod.Name, pod.IPAddress)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			continue
 		}
 
@@ -130,7 +142,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("invalid route for Pod '%s' - bad next hop; have %s, expecting %s",
 				pod.Name, lookUpRoute.Ipr.NextHopAddr, pod.IPAddress)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			routeMap[lookUpRoute.Ipr.DstAddr] = false
 		}
 
@@ -138,13 +150,13 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("Pod interface index %d does not match static route interface index %d",
 				pod.VppSwIfIdx, lookUpRoute.IprMeta.OutgoingIfIdx)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			routeMap[lookUpRoute.Ipr.DstAddr] = false
 		}
 		if pod.VppIfName != lookUpRoute.Ipr.OutIface {
 			errString := fmt.Sprintf("Name of pod interface %s differs from route interface name %s",
 				pod.VppIfInternalName, lookUpRoute.Ipr.OutIface)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 			routeMap[lookUpRoute.Ipr.DstAddr] = false
 		}
@@ -155,7 +167,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("route for Pod %s with vppIfIP Address %s does not exist ",
 				pod.Name, pod.IPAddress)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			continue
 		}
 
@@ -163,7 +175,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("Pod %s IP %s does not match with route %+v next hop IP %s",
 				pod.Name, pod.IPAddress, lookUpRoute, lookUpRoute.Ipr.NextHopAddr)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			routeMap[podIfIProute.Ipr.DstAddr] = false
 		}
 
@@ -171,7 +183,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			numErrs++
 			errString := fmt.Sprintf("Pod interface index %d does not match static route interface index %d",
 				pod.VppSwIfIdx, lookUpRoute.IprMeta.OutgoingIfIdx)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			routeMap[podIfIProute.Ipr.DstAddr] = false
 		}
 
@@ -180,7 +192,7 @@ func (v *Validator) validateVrf1PodRoutes(node *telemetrymodel.Node, vrfMap map[
 			errString := fmt.Sprintf("Name of pod interface %s differs from route interface name %s",
 				pod.VppIfInternalName, lookUpRoute.Ipr.OutIface)
 
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			routeMap[podIfIProute.Ipr.DstAddr] = false
 		}
 
@@ -205,19 +217,19 @@ func (v *Validator) validateVrf0GigERoutes(node *telemetrymodel.Node, vrfMap map
 	gigeRoute, ok := vrfMap[0][node.IPAddr]
 	if !ok {
 		errString := fmt.Sprintf("route with dst ip %s not found", node.IPAddr)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 	if gigeRoute.Ipr.DstAddr != node.IPAddr {
 		errString := fmt.Sprintf("route %s has different dst ip %s than node %s ip %s",
 			gigeRoute.IprMeta.TableName, gigeRoute.Ipr.DstAddr, node.Name, node.IPAddr)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 	if !strings.Contains(gigeRoute.Ipr.OutIface, "GigabitEthernet") {
 		errString := fmt.Sprintf("route with dst IP %s had different out interface %s than "+
 			"expected GigabitEthernet0/8/0", gigeRoute.Ipr.DstAddr, gigeRoute.Ipr.OutIface)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 
@@ -227,7 +239,7 @@ func (v *Validator) validateVrf0GigERoutes(node *telemetrymodel.Node, vrfMap map
 		errString := fmt.Sprintf("interface %s has different interface index %d than route "+
 			"with dst ip %s interface index %d",
 			intf.IfMeta.Tag, intf.IfMeta.SwIfIndex, gigeRoute.Ipr.DstAddr, gigeRoute.IprMeta.OutgoingIfIdx)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 
@@ -241,7 +253,7 @@ func (v *Validator) validateVrf0GigERoutes(node *telemetrymodel.Node, vrfMap map
 	if !gigEIPFound {
 		errString := fmt.Sprintf("interface %s with index %d does not have a matching ip for dst ip %s",
 			intf.IfMeta.Tag, intf.IfMeta.SwIfIndex, gigeRoute.Ipr.DstAddr)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 
@@ -252,28 +264,28 @@ func (v *Validator) validateVrf0GigERoutes(node *telemetrymodel.Node, vrfMap map
 		route, ok := vrfMap[0][dstIP+"/32"]
 		if !ok {
 			errString := fmt.Sprintf("route with dst ip %s not found", dstIP+"/32")
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 		}
 		ip, _ := separateIPandMask(route.Ipr.DstAddr)
 		if ip != route.Ipr.NextHopAddr {
 			errString := fmt.Sprintf("Dst IP %s and next hop IP %s dont match for route %s",
 				route.Ipr.NextHopAddr, route.Ipr.DstAddr, route.Ipr.OutIface)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 		}
 
 		if !strings.Contains(route.Ipr.OutIface, "GigabitEthernet") {
 			errString := fmt.Sprintf("Route with dst IP %s has an out interface %s instead of"+
 				"GigabitEthernet0/8/0", otherNode.IPAddr, route.Ipr.OutIface)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 		}
 
 		if route.IprMeta.OutgoingIfIdx != gigeRoute.IprMeta.OutgoingIfIdx {
 			errString := fmt.Sprintf("Route %s has an outgoing interface index of %d instead of %d",
 				route.IprMeta.TableName, route.IprMeta.OutgoingIfIdx, gigeRoute.IprMeta.OutgoingIfIdx)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 		}
 	}
@@ -295,7 +307,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 		if !ok {
 			errString := fmt.Sprintf("Route for pod network for node %s with ip %s not found",
 				othNode.Name, podNwIP)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 		}
 
@@ -305,7 +317,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 				if bd.BdMeta.BdID2Name[route.IprMeta.OutgoingIfIdx] != "vxlanBVI" {
 					errString := fmt.Sprintf("vxlanBD outgoing interface for ipr index %d for route "+
 						"with pod network ip %s is not vxlanBVI", route.IprMeta.OutgoingIfIdx, podNwIP)
-					v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+					v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 					numErrs++
 				}
 			}
@@ -314,7 +326,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 					if !intf.BVI {
 						errString := fmt.Sprintf("Bridge domain %s interface %s BVI is %+v, expected true",
 							bd.Bd.Name, intf.Name, intf.BVI)
-						v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+						v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 						numErrs++
 					}
 				}
@@ -336,7 +348,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 						errString := fmt.Sprintf("no matching ip found in remote node %s interface "+
 							"%s to match current node %s route next hop %s",
 							othNode.Name, intf.If.Name, node.Name, route.Ipr.NextHopAddr)
-						v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+						v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 					}
 				}
 			}
@@ -346,7 +358,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 		if !ok {
 			errString := fmt.Sprintf("could not find route to node %s with ip %s from vrf0",
 				othNode.Name, othNode.ManIPAddr+"/32")
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			//err
 			numErrs++
 		}
@@ -354,7 +366,7 @@ func (v *Validator) validateRemoteNodeRoutes(node *telemetrymodel.Node, vrfMap m
 		if vrf0ToRemoteRoute.Ipr.DstAddr != othNode.ManIPAddr+"/32" {
 			errString := fmt.Sprintf("vrf0 to remote route dst ip %s is different than node %s man ip %s",
 				vrf0ToRemoteRoute.Ipr.DstAddr, node.Name, node.ManIPAddr)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			//err wrong dest.
 			numErrs++
 		}
@@ -375,7 +387,7 @@ func (v *Validator) validateVrf0LocalHostRoute(node *telemetrymodel.Node, vrfMap
 	localRoute, ok := vrfMap[0][node.ManIPAddr+"/32"]
 	if !ok {
 		errString := fmt.Sprintf("missing route with dst IP %s for node %s", node.ManIPAddr+"/32", node.Name)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 
@@ -384,20 +396,20 @@ func (v *Validator) validateVrf0LocalHostRoute(node *telemetrymodel.Node, vrfMap
 		errString := fmt.Sprintf("node %s interface with idx %d from route with ip %s does not "+
 			"match tag tap-vpp2 instead is %s",
 			node.Name, localRoute.IprMeta.OutgoingIfIdx, localRoute.Ipr.DstAddr, tapIntf.IfMeta.Tag)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 
 	}
 	if tapIntf.IfMeta.SwIfIndex != localRoute.IprMeta.OutgoingIfIdx {
 		errString := fmt.Sprintf("tap interface index %d dot not match route outgoing index %d",
 			tapIntf.IfMeta.SwIfIndex, localRoute.IprMeta.OutgoingIfIdx)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 		//err mismatch indexes
 	}
 	if localRoute.Ipr.NextHopAddr == "" {
 		errString := fmt.Sprintf("local route with dst ip %s is missing a next hop ip", localRoute.Ipr.DstAddr)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 	}
 
@@ -411,7 +423,7 @@ func (v *Validator) validateVrf1DefaultRoute(node *telemetrymodel.Node, vrfMap m
 	defaultRoute, ok := vrfMap[1]["0.0.0.0/0"]
 	if !ok {
 		errString := fmt.Sprintf("default route 0.0.0.0/0 missing for node %s", node.Name)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 		//err default route is missing
 	}
@@ -419,7 +431,7 @@ func (v *Validator) validateVrf1DefaultRoute(node *telemetrymodel.Node, vrfMap m
 	if defaultRoute.IprMeta.OutgoingIfIdx != 0 {
 		errString := fmt.Sprintf("expeceted default route 0.0.0.0/0 to have outgoing "+
 			"interface index of 0, got %d", defaultRoute.IprMeta.OutgoingIfIdx)
-		v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 		numErrs++
 		//err index does not match vrf 0 index - mismatch
 	}
@@ -432,7 +444,7 @@ func (v *Validator) validateRouteToLocalLoopInterface(node *telemetrymodel.Node,
 	numErrs := 0
 	loopIf, err := datastore.GetNodeLoopIFInfo(node)
 	if err != nil {
-		v.Report.LogErrAndAppendToNodeReport(node.Name, err.Error())
+		v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, err.Error())
 	}
 
 	//validateRouteToLocalNodeLoopInterface
@@ -440,7 +452,7 @@ func (v *Validator) validateRouteToLocalLoopInterface(node *telemetrymodel.Node,
 		route, ok := vrfMap[1][ip]
 		if !ok {
 			errString := fmt.Sprintf("Static route for node %s with ip %s not found", node.Name, ip)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 			routeMap[route.Ipr.DstAddr] = false
 		}
@@ -448,7 +460,7 @@ func (v *Validator) validateRouteToLocalLoopInterface(node *telemetrymodel.Node,
 		if route.Ipr.DstAddr != ip {
 			errString := fmt.Sprintf("Node %s loop interface ip %s does not match static route ip %s",
 				node.Name, ip, route.Ipr.DstAddr)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 			routeMap[route.Ipr.DstAddr] = false
 		}
@@ -456,14 +468,14 @@ func (v *Validator) validateRouteToLocalLoopInterface(node *telemetrymodel.Node,
 		if loopIf.IfMeta.SwIfIndex != route.IprMeta.OutgoingIfIdx {
 			errString := fmt.Sprintf("Node %s loop interface idx %d does not match static route idx %d",
 				node.Name, loopIf.IfMeta.SwIfIndex, route.IprMeta.OutgoingIfIdx)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 			routeMap[route.Ipr.DstAddr] = false
 		}
 		if loopIf.IfMeta.Tag != route.Ipr.OutIface {
 			errString := fmt.Sprintf("Node %s loop interface tag %s does not match static route tag %s",
 				node.Name, loopIf.IfMeta.Tag, route.Ipr.OutIface)
-			v.Report.LogErrAndAppendToNodeReport(node.Name, errString)
+			v.Report.LogErrAndAppendToNodeReportWithCategory(node.Name, api.CategoryRoutes, errString)
 			numErrs++
 			routeMap[route.Ipr.DstAddr] = false
 		}
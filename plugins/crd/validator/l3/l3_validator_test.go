@@ -95,7 +95,7 @@ func TestValidator(t *testing.T) {
 	vtv.nodeKey = "k8s-master"
 	vtv.vppCache = datastore.NewVppDataStore()
 	vtv.k8sCache = datastore.NewK8sDataStore()
-	vtv.report = datastore.NewSimpleReport(vtv.log)
+	vtv.report = datastore.NewSimpleReport(vtv.log, 0)
 
 	// Initialize the validators
 	vtv.l2Validator = &l2.Validator{
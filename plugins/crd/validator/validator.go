@@ -16,12 +16,50 @@
 package validator
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/contiv/vpp/plugins/crd/api"
+	"github.com/contiv/vpp/plugins/crd/datastore"
 	"github.com/contiv/vpp/plugins/crd/validator/l2"
 	"github.com/contiv/vpp/plugins/crd/validator/l3"
 	"github.com/ligato/cn-infra/logging"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
 )
 
+const (
+	// defaultMaxReportFiles is the number of report files kept in the
+	// report directory if MaxReportFiles is not set.
+	defaultMaxReportFiles = 10
+	// reportFileTimeFormat is the format of the timestamp in report file
+	// names; file names sort in the order in which the reports were written.
+	reportFileTimeFormat = "20060102-150405.000000000"
+)
+
+// HealthSummary is an aggregate summary of the health of a Contiv cluster
+// produced by a validation run. Details about the errors found are
+// recorded in the validation report.
+type HealthSummary struct {
+	TimeStamp             time.Time        `json:"timeStamp"`
+	NodeCount             int              `json:"nodeCount"`
+	NodesWithCompleteData int              `json:"nodesWithCompleteData"`
+	ErrorCount            int              `json:"errorCount"`
+	WarningCount          int              `json:"warningCount"`
+	Passed                bool             `json:"passed"`
+	Categories            []CategoryHealth `json:"categories"`
+}
+
+// CategoryHealth is the result of a single validation category.
+type CategoryHealth struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	ErrorCount int    `json:"errorCount"`
+}
+
 // Validator is the implementation of the ContivTelemetryProcessor interface.
 type Validator struct {
 	Deps
@@ -29,6 +67,20 @@ type Validator struct {
 	VppCache api.VppCache
 	K8sCache api.K8sCache
 	Report   api.Report
+
+	// ReportDir, if set, is the directory into which the report is written
+	// in JSON format after each validation, into a file named
+	// report-<timestamp>.json. Only the latest MaxReportFiles report files
+	// are kept in the directory (defaultMaxReportFiles if not set).
+	ReportDir      string
+	MaxReportFiles int
+
+	// Workers is the number of nodes validated concurrently by the L2
+	// validation. Nodes are validated one at a time when not set.
+	Workers int
+
+	callbackMtx         sync.Mutex
+	completionCallbacks []func(report api.Report)
 }
 
 // Deps lists dependencies of PolicyCache.
@@ -41,11 +93,21 @@ type Deps struct {
 // Validate performs the validation of all layers of telemetry data
 // collected from a Contiv cluster.
 func (v *Validator) Validate() {
+	v.validate()
+}
+
+// validate performs the validation of all layers of telemetry data and
+// returns the results of the individual validations. The report is time
+// stamped with the start of the validation.
+func (v *Validator) validate() []api.ValidationResult {
+	v.Report.SetTimeStamp(time.Now())
+
 	l2Validator := &l2.Validator{
 		Log:      v.L2Log,
 		VppCache: v.VppCache,
 		K8sCache: v.K8sCache,
 		Report:   v.Report,
+		Workers:  v.Workers,
 	}
 	l2Validator.Validate()
 
@@ -57,4 +119,101 @@ func (v *Validator) Validate() {
 	}
 	l3Validator.Validate()
 
+	if v.ReportDir != "" {
+		if err := v.persistReport(v.Report.GetTimeStamp()); err != nil {
+			v.Log.Errorf("failed to persist validation report: %s", err)
+		}
+	}
+
+	v.callbackMtx.Lock()
+	callbacks := v.completionCallbacks
+	v.callbackMtx.Unlock()
+	for _, callback := range callbacks {
+		callback(v.Report)
+	}
+	return append(l2Validator.Results(), l3Validator.Results()...)
+}
+
+// OnValidationComplete registers a callback that is invoked with the
+// validation report each time Validate() finishes, e.g. to post the report
+// to a webhook or to fail a CI pipeline. Callbacks are invoked in the order
+// in which they were registered.
+func (v *Validator) OnValidationComplete(callback func(report api.Report)) {
+	v.callbackMtx.Lock()
+	defer v.callbackMtx.Unlock()
+	v.completionCallbacks = append(v.completionCallbacks, callback)
+}
+
+// persistReport writes the report into a timestamped file in ReportDir and
+// removes the oldest report files beyond MaxReportFiles.
+func (v *Validator) persistReport(timestamp time.Time) error {
+	buf, err := json.MarshalIndent(v.Report.RetrieveReport(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(v.ReportDir, 0755); err != nil {
+		return err
+	}
+	fileName := fmt.Sprintf("report-%s.json", timestamp.UTC().Format(reportFileTimeFormat))
+	if err := ioutil.WriteFile(filepath.Join(v.ReportDir, fileName), buf, 0644); err != nil {
+		return err
+	}
+
+	maxFiles := v.MaxReportFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultMaxReportFiles
+	}
+	files, err := filepath.Glob(filepath.Join(v.ReportDir, "report-*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for len(files) > maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// ClusterHealthSummary runs all validations and returns an aggregate
+// summary of the cluster's health. The validations record their findings
+// in the report as usual; the summary holds the result of each validation
+// and the number of error and warning entries recorded by the validations.
+// The summary has the time stamp of the report.
+func (v *Validator) ClusterHealthSummary() *HealthSummary {
+	before := v.Report.CountBySeverity()
+	results := v.validate()
+	after := v.Report.CountBySeverity()
+
+	summary := &HealthSummary{
+		TimeStamp:    v.Report.GetTimeStamp(),
+		ErrorCount:   after[api.SeverityError] - before[api.SeverityError],
+		WarningCount: after[api.SeverityWarning] - before[api.SeverityWarning],
+		Categories:   make([]CategoryHealth, 0, len(results)),
+	}
+	summary.Passed = summary.ErrorCount == 0
+
+	for _, node := range v.VppCache.RetrieveAllNodes() {
+		summary.NodeCount++
+		if len(datastore.MissingNodeData(node)) == 0 {
+			summary.NodesWithCompleteData++
+		}
+	}
+	if summary.NodesWithCompleteData != summary.NodeCount {
+		summary.Passed = false
+	}
+
+	for _, result := range results {
+		category := CategoryHealth{
+			Name:       result.Name,
+			Passed:     result.ErrorCount == 0,
+			ErrorCount: result.ErrorCount,
+		}
+		summary.Passed = summary.Passed && category.Passed
+		summary.Categories = append(summary.Categories, category)
+	}
+	return summary
 }
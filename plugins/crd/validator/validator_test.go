@@ -0,0 +1,180 @@
+// Copyright (c) 2018 Cisco and/or its affiliates.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"encoding/json"
+	"github.com/contiv/vpp/plugins/crd/api"
+	"github.com/contiv/vpp/plugins/crd/cache/telemetrymodel"
+	"github.com/contiv/vpp/plugins/crd/datastore"
+	"github.com/contiv/vpp/plugins/crd/testdata"
+	"github.com/ligato/cn-infra/logging"
+	"github.com/ligato/cn-infra/logging/logrus"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestValidator() *Validator {
+	log := logrus.DefaultLogger()
+	log.SetLevel(logging.ErrorLevel)
+
+	v := &Validator{
+		Deps: Deps{
+			Log:   log,
+			L2Log: log,
+			L3Log: log,
+		},
+		VppCache: datastore.NewVppDataStore(),
+		K8sCache: datastore.NewK8sDataStore(),
+		Report:   datastore.NewSimpleReport(log, 0),
+	}
+
+	gomega.Expect(testdata.CreateNodeTestData(v.VppCache)).To(gomega.Succeed())
+	gomega.Expect(testdata.CreateK8sPodTestData(v.K8sCache)).To(gomega.Succeed())
+	gomega.Expect(testdata.CreateK8sNodeTestData(v.K8sCache)).To(gomega.Succeed())
+
+	for _, node := range v.VppCache.RetrieveAllNodes() {
+		gomega.Expect(v.VppCache.SetSecondaryNodeIndices(node)).To(gomega.BeEmpty())
+		for _, pod := range v.K8sCache.RetrieveAllPods() {
+			if pod.HostIPAddress == node.ManIPAddr {
+				node.PodMap[pod.Name] = pod
+			}
+		}
+	}
+	return v
+}
+
+func TestValidator_ClusterHealthSummary(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	v := newTestValidator()
+	v.Report = datastore.NewSimpleReport(v.Log, 1)
+
+	summary := v.ClusterHealthSummary()
+
+	gomega.Expect(summary.NodeCount).To(gomega.Equal(3))
+	gomega.Expect(summary.NodesWithCompleteData).To(gomega.Equal(3))
+	gomega.Expect(summary.ErrorCount).To(gomega.BeZero())
+	gomega.Expect(summary.Passed).To(gomega.BeTrue())
+	gomega.Expect(summary.Categories).NotTo(gomega.BeEmpty())
+	for _, category := range summary.Categories {
+		gomega.Expect(category.Passed).To(gomega.BeTrue(), category.Name)
+		gomega.Expect(category.ErrorCount).To(gomega.BeZero(), category.Name)
+	}
+	gomega.Expect(summary.Categories).To(gomega.ContainElement(CategoryHealth{Name: "BD", Passed: true}))
+	gomega.Expect(summary.Categories).To(gomega.ContainElement(CategoryHealth{Name: "L3", Passed: true}))
+
+	_, err := json.Marshal(summary)
+	gomega.Expect(err).To(gomega.BeNil())
+
+	// The summary has the time stamp of its report and of the report's
+	// snapshot in the history
+	gomega.Expect(summary.TimeStamp).NotTo(gomega.BeZero())
+	gomega.Expect(summary.TimeStamp).To(gomega.Equal(v.Report.GetTimeStamp()))
+	v.Report.PushSnapshot()
+	gomega.Expect(v.Report.History()[0].TimeStamp).To(gomega.Equal(summary.TimeStamp))
+
+	// The results do not depend on the summaries being recorded in the
+	// report, nor on unrelated global messages that look like summaries
+	v.Report.Clear()
+	v.Report.(*datastore.SimpleReport).MinSeverity = api.SeverityError
+	v.Report.AppendToNodeReport(api.GlobalMsg, "Bogus validation: 3 errors found")
+	quiet := v.ClusterHealthSummary()
+	gomega.Expect(quiet.Categories).To(gomega.Equal(summary.Categories))
+	gomega.Expect(quiet.ErrorCount).To(gomega.BeZero())
+	gomega.Expect(quiet.WarningCount).To(gomega.BeZero())
+	gomega.Expect(quiet.Passed).To(gomega.BeTrue())
+	v.Report.(*datastore.SimpleReport).MinSeverity = api.SeverityInfo
+
+	// A fault is reflected in the summary
+	v.Report.Clear()
+	node, err := v.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	node.NodeL2Fibs = nil
+
+	summary = v.ClusterHealthSummary()
+	gomega.Expect(summary.NodesWithCompleteData).To(gomega.Equal(2))
+	gomega.Expect(summary.ErrorCount).NotTo(gomega.BeZero())
+	gomega.Expect(summary.Passed).To(gomega.BeFalse())
+}
+
+func TestValidator_OnValidationComplete(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	v := newTestValidator()
+
+	reports := make([]map[string][]string, 0)
+	v.OnValidationComplete(func(report api.Report) {
+		gomega.Expect(report).To(gomega.BeIdenticalTo(v.Report))
+		reports = append(reports, report.RetrieveReport().DeepCopy())
+	})
+
+	v.Validate()
+	gomega.Expect(reports).To(gomega.HaveLen(1))
+	gomega.Expect(reports[0][api.GlobalMsg]).To(gomega.ContainElement("BD validation: OK"))
+	gomega.Expect(reports[0][api.GlobalMsg]).To(gomega.ContainElement("success validating l3 info."))
+
+	// A fault is reflected in the report passed to the callback
+	v.Report.Clear()
+	node, err := v.VppCache.RetrieveNode("k8s-master")
+	gomega.Expect(err).To(gomega.BeNil())
+	node.NodeIPArp = nil
+
+	v.Validate()
+	gomega.Expect(reports).To(gomega.HaveLen(2))
+	gomega.Expect(reports[1][api.GlobalMsg]).NotTo(gomega.ContainElement("ARP validation: OK"))
+}
+
+func TestValidator_ReportDir(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	v := newTestValidator()
+
+	dir, err := ioutil.TempDir("", "reports")
+	gomega.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+
+	v.ReportDir = dir
+	v.Validate()
+
+	// The report file is named after the report's time stamp
+	files, err := filepath.Glob(filepath.Join(dir, "report-*.json"))
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(files).To(gomega.Equal([]string{filepath.Join(dir,
+		"report-"+v.Report.GetTimeStamp().UTC().Format(reportFileTimeFormat)+".json")}))
+
+	buf, err := ioutil.ReadFile(files[0])
+	gomega.Expect(err).To(gomega.BeNil())
+	report := telemetrymodel.Reports{}
+	gomega.Expect(json.Unmarshal(buf, &report)).To(gomega.Succeed())
+	gomega.Expect(report).To(gomega.Equal(v.Report.RetrieveReport()))
+	gomega.Expect(report[api.GlobalMsg]).To(gomega.ContainElement("BD validation: OK"))
+
+	// Only the latest report files are kept
+	gomega.Expect(os.Remove(files[0])).To(gomega.Succeed())
+	v.MaxReportFiles = 2
+	start := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		gomega.Expect(v.persistReport(start.Add(time.Duration(i) * time.Second))).To(gomega.Succeed())
+	}
+
+	files, err = filepath.Glob(filepath.Join(dir, "report-*.json"))
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(files).To(gomega.Equal([]string{
+		filepath.Join(dir, "report-20180701-120001.000000000.json"),
+		filepath.Join(dir, "report-20180701-120002.000000000.json"),
+	}))
+}